@@ -0,0 +1,90 @@
+package gor
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Builder records middlewares and routes to register them on a new Mux at once,
+// reporting all of their problems instead of panicking at the first one.
+// It is meant for routes loaded from a configuration, e.g.
+//
+//	b := gor.NewBuilder()
+//	for _, rt := range cfg.Routes {
+//		b.Method(rt.Method, rt.Pattern, handlers[rt.Handler])
+//	}
+//	r, err := b.Build()
+//
+// The errors are *RouteError values joined in registration order,
+// which can be checked with errors.Is against the Err* errors.
+type Builder struct {
+	opts  []Option
+	steps []func(mx *Mux) error
+}
+
+// NewBuilder returns a new Builder of a Mux configured with the options.
+func NewBuilder(opts ...Option) *Builder {
+	return &Builder{opts: opts}
+}
+
+// Use records middlewares to append to the Mux middleware stack, see Mux.Use.
+func (b *Builder) Use(middlewares ...func(http.Handler) http.Handler) *Builder {
+	return b.add(func(mx *Mux) error {
+		return mx.TryUse(middlewares...)
+	})
+}
+
+// Handle records a `pattern` route matching any http method, see Mux.Handle.
+func (b *Builder) Handle(pattern string, handler http.Handler) *Builder {
+	return b.add(func(mx *Mux) error {
+		return mx.TryHandle(pattern, handler)
+	})
+}
+
+// Method records a `pattern` route matching the `method` http method, see Mux.Method.
+func (b *Builder) Method(method, pattern string, handler http.Handler) *Builder {
+	return b.add(func(mx *Mux) error {
+		return mx.TryMethod(method, pattern, handler)
+	})
+}
+
+// Mount records a handler or a sub-router to mount along the `pattern`, see Mux.Mount.
+func (b *Builder) Mount(pattern string, handler http.Handler) *Builder {
+	return b.add(func(mx *Mux) error {
+		return mx.TryMount(pattern, handler)
+	})
+}
+
+// Validate reports all of the problems of the recorded middlewares and routes, or nil if there are none.
+func (b *Builder) Validate() error {
+	_, err := b.build()
+	return err
+}
+
+// Build returns a new Mux with the recorded middlewares and routes,
+// or nil and all of their problems if any of them cannot be registered.
+func (b *Builder) Build() (*Mux, error) {
+	mx, err := b.build()
+	if err != nil {
+		return nil, err
+	}
+	return mx, nil
+}
+
+func (b *Builder) add(step func(mx *Mux) error) *Builder {
+	b.steps = append(b.steps, step)
+	return b
+}
+
+func (b *Builder) build() (*Mux, error) {
+	mx := NewMux(b.opts...)
+
+	var errs []error
+	for _, step := range b.steps {
+		if err := step(mx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return mx, errors.Join(errs...)
+}
@@ -0,0 +1,131 @@
+package gor
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRouteErrors(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	tests := []struct {
+		pattern string
+		err     error
+		pos     int
+	}{
+		{"users", ErrInvalidPattern, 0},
+		{"/users/{id", ErrInvalidPattern, 7},
+		{"/files/*x", ErrInvalidPattern, 7},
+		{"/files/{path...}x", ErrInvalidPattern, 16},
+		{"/users/{id:[0-9}", ErrInvalidPattern, 7},
+		{"/users/{id:(}", ErrInvalidPattern, 7},
+		{"/users/{id}/{id}", ErrDuplicateParam, 12},
+		{"/reports/{year?}/{month}", ErrInvalidPattern, 17},
+		{"/reports/v{year?}", ErrInvalidPattern, 10},
+	}
+
+	for _, tt := range tests {
+		err := NewRouter().TryHandle(tt.pattern, h)
+		if !errors.Is(err, tt.err) {
+			t.Fatalf("%s: expecting error %v, got %v", tt.pattern, tt.err, err)
+		}
+
+		var rerr *RouteError
+		if !errors.As(err, &rerr) || rerr.Pos != tt.pos || rerr.Pattern != tt.pattern {
+			t.Fatalf("%s: expecting a route error at %d, got %#v", tt.pattern, tt.pos, rerr)
+		}
+	}
+
+	r := NewRouter()
+	if err := r.TryMethod("BOGUS", "/", h); !errors.Is(err, ErrUnsupportedMethod) {
+		t.Fatalf("expecting %v, got %v", ErrUnsupportedMethod, err)
+	}
+	if err := r.TryMount("/api", h); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.TryMount("/api", h); !errors.Is(err, ErrMountConflict) {
+		t.Fatalf("expecting %v, got %v", ErrMountConflict, err)
+	}
+	if err := r.TryMount("/nil", nil); !errors.Is(err, ErrNilHandler) {
+		t.Fatalf("expecting %v, got %v", ErrNilHandler, err)
+	}
+	for _, pattern := range []string{"", "api", "/users/{id"} {
+		if err := r.TryMount(pattern, h); !errors.Is(err, ErrInvalidPattern) {
+			t.Fatalf("%q: expecting %v, got %v", pattern, ErrInvalidPattern, err)
+		}
+	}
+	if err := r.TryUse(func(next http.Handler) http.Handler { return next }); !errors.Is(err, ErrMiddlewareOrder) {
+		t.Fatalf("expecting %v, got %v", ErrMiddlewareOrder, err)
+	}
+
+	r.Named("home").Get("/", h)
+	if err := r.Named("home").(*Mux).TryHandle("/home", h); !errors.Is(err, ErrDuplicateName) {
+		t.Fatalf("expecting %v, got %v", ErrDuplicateName, err)
+	}
+	if _, err := r.URLFor("home"); err != nil {
+		t.Fatal(err)
+	}
+
+	// failed registrations leave the routing tree untouched
+	if err := r.TryHandle("/users/{id}/{id}", h); err == nil {
+		t.Fatal("expecting an error")
+	}
+	if r.Match(NewRouteContext(), "GET", "/users/1/2") {
+		t.Fatal("unexpected route registered by a failed registration")
+	}
+
+	defer func() {
+		if err, ok := recover().(error); !ok || !errors.Is(err, ErrDuplicateParam) {
+			t.Fatalf("expecting a panic with %v, got %v", ErrDuplicateParam, err)
+		}
+	}()
+	r.Get("/users/{id}/{id}", h)
+}
+
+func TestBuilder(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	})
+
+	b := NewBuilder(WithTrailingSlash(TrailingSlashStrip))
+	b.Use(func(next http.Handler) http.Handler { return next })
+	b.Method("GET", "/users/{id}", h)
+	b.Method("FETCH", "/users", h)
+	b.Handle("/files/{path", h)
+	b.Use(func(next http.Handler) http.Handler { return next })
+	b.Mount("/admin", h)
+	b.Mount("/admin", h)
+
+	err := b.Validate()
+	for _, target := range []error{ErrUnsupportedMethod, ErrInvalidPattern, ErrMiddlewareOrder, ErrMountConflict} {
+		if !errors.Is(err, target) {
+			t.Fatalf("expecting the errors to report %v, got %v", target, err)
+		}
+	}
+	if n := len(err.(interface{ Unwrap() []error }).Unwrap()); n != 4 {
+		t.Fatalf("expecting 4 errors, got %d: %v", n, err)
+	}
+
+	if r, err := b.Build(); r != nil || err == nil {
+		t.Fatal("expecting Build to fail")
+	}
+
+	r, err := NewBuilder(WithTrailingSlash(TrailingSlashStrip)).
+		Method("GET", "/users/{id}", h).
+		Mount("/admin", h).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	for _, path := range []string{"/users/1", "/users/1/", "/admin/x"} {
+		if _, body := testRequest(t, ts, "GET", path, nil); body != "ok" {
+			t.Fatalf("%s: expecting 'ok', got '%s'", path, body)
+		}
+	}
+}
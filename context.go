@@ -3,7 +3,9 @@ package gor
 import (
 	"context"
 	"net/http"
+	"sort"
 	"strings"
+	"time"
 )
 
 // Context is the default routing context set on the root node of the
@@ -20,6 +22,10 @@ type Context struct {
 	RoutePath   string
 	RouteMethod string
 
+	// URLFormat is the extension of the request path, e.g. "json", trimmed from the routing path
+	// by the URLFormat middleware. Negotiate serves the media type of the extension when it is set.
+	URLFormat string
+
 	// URLParams are the stack of routeParams captured during the routing lifecycle in the sub-routers stack.
 	URLParams RouteParams
 
@@ -35,8 +41,30 @@ type Context struct {
 	// This is a record of all matching patterns in the sub-routers stack.
 	RoutePatterns []string
 
+	// Metadata stack of the matching routes in the sub-routers stack.
+	routeMetas []Meta
+
+	// Methods supported by the route found for the path when the method is not allowed.
+	methodsAllowed []methodType
+
 	// methodNotAllowed hint
 	methodNotAllowed bool
+
+	// Match the static path segments of the current sub-router case-insensitively.
+	foldCase bool
+
+	// Trace of the route lookup, recorded by Mux.Explain and for the trace header.
+	trace *Explanation
+
+	// API version selected by a Versioned router.
+	apiVersion int
+
+	// mounted reports whether the request is served by a handler mounted directly, rather than by a route endpoint.
+	mounted bool
+
+	// derived reports whether Done was called, e.g. by a context derived from this one with context.WithTimeout,
+	// which uses it until it is canceled, so that it is not reused for another request.
+	derived bool
 }
 
 // contextKey is a value to be used with context.WithValue.
@@ -59,6 +87,23 @@ func NewRouteContext() *Context {
 	return &Context{}
 }
 
+// requestContext is the routing context of a request served by a router, pooled along with the copy of the request
+// it is the context of, so that dispatching the request does not allocate.
+type requestContext struct {
+	Context
+	req http.Request
+}
+
+// newPooledRouteContext returns a new routing Context object with its stacks pre-sized,
+// so that routing most requests does not grow them.
+func newPooledRouteContext() *Context {
+	return &Context{
+		URLParams:     RouteParams{Keys: make([]string, 0, 8), Values: make([]string, 0, 8)},
+		routeParams:   RouteParams{Keys: make([]string, 0, 8), Values: make([]string, 0, 8)},
+		RoutePatterns: make([]string, 0, 4),
+	}
+}
+
 func (k *contextKey) String() string {
 	return "chi context value " + k.name
 }
@@ -74,44 +119,131 @@ func (ctx *Context) Reset() {
 	ctx.Routes = nil
 	ctx.RoutePath = ""
 	ctx.RouteMethod = ""
+	ctx.URLFormat = ""
 	ctx.RoutePatterns = ctx.RoutePatterns[:0]
 	ctx.URLParams.Keys = ctx.URLParams.Keys[:0]
 	ctx.URLParams.Values = ctx.URLParams.Values[:0]
 	ctx.routePattern = ""
 	ctx.routeParams.Keys = ctx.routeParams.Keys[:0]
 	ctx.routeParams.Values = ctx.routeParams.Values[:0]
+	ctx.routeMetas = ctx.routeMetas[:0]
+	ctx.methodsAllowed = ctx.methodsAllowed[:0]
 	ctx.methodNotAllowed = false
+	ctx.foldCase = false
+	ctx.trace = nil
+	ctx.apiVersion = 0
+	ctx.mounted = false
+	ctx.derived = false
 	ctx.parentCtx = nil
 }
 
+// Deadline returns the deadline of the parent context, see context.Context.
+// The routing context set by Mux.ServeHTTP is the context of the request,
+// wrapping the context the request had before.
+func (ctx *Context) Deadline() (deadline time.Time, ok bool) {
+	return ctx.parent().Deadline()
+}
+
+// Done returns the done channel of the parent context, see context.Context.
+func (ctx *Context) Done() <-chan struct{} {
+	if !ctx.derived {
+		ctx.derived = true
+	}
+	return ctx.parent().Done()
+}
+
+// Err returns the error of the parent context, see context.Context.
+func (ctx *Context) Err() error {
+	return ctx.parent().Err()
+}
+
+// Value returns the routing context itself for RouteCtxKey, and the value of the parent context otherwise,
+// see context.Context.
+func (ctx *Context) Value(key interface{}) interface{} {
+	if key == RouteCtxKey {
+		return ctx
+	}
+	return ctx.parent().Value(key)
+}
+
+func (ctx *Context) parent() context.Context {
+	if ctx.parentCtx == nil {
+		return context.Background()
+	}
+	return ctx.parentCtx
+}
+
 // URLParam returns the corresponding URL parameter from the request routing context.
 func (ctx *Context) URLParam(key string) string {
+	value, _ := ctx.URLParamOk(key)
+	return value
+}
+
+// URLParamOk returns the corresponding URL parameter from the request routing context
+// and whether it is present, telling the optional params absent from the path apart from the empty ones.
+func (ctx *Context) URLParamOk(key string) (string, bool) {
 	for k := len(ctx.URLParams.Keys) - 1; k >= 0; k-- {
 		if ctx.URLParams.Keys[k] == key {
-			return ctx.URLParams.Values[k]
+			return ctx.URLParams.Values[k], true
 		}
 	}
-	return ""
+	return "", false
 }
 
 // RoutePattern builds a routing pattern string for a particular request at a particular routing point.
 // This means that the value will change throughout the request execution time in the router.
 // Therefore, it is recommended to use this value only after the next handler is called.
 func (ctx *Context) RoutePattern() string {
-	routePattern := strings.Join(ctx.RoutePatterns, "")
-	routePattern = replaceWildcards(routePattern)
+	var b strings.Builder
+	for i, p := range ctx.RoutePatterns {
+		if i < len(ctx.RoutePatterns)-1 {
+			// drop the wildcards connecting the sub-routers
+			p = strings.TrimSuffix(p, "/*")
+		}
+		b.WriteString(p)
+	}
+
+	routePattern := b.String()
 	routePattern = strings.TrimSuffix(routePattern, "//")
 	routePattern = strings.TrimSuffix(routePattern, "/")
 
 	return routePattern
 }
 
-// replaceWildcards takes a route pattern and recursively replaces all occurrences of "/*/" to "/".
-func replaceWildcards(p string) string {
-	if strings.Contains(p, "/*/") {
-		return replaceWildcards(strings.Replace(p, "/*/", "/", -1))
+// APIVersion returns the API version selected by a Versioned router, or 0 if there is none.
+func (ctx *Context) APIVersion() int {
+	return ctx.apiVersion
+}
+
+// AllowedMethods returns the sorted list of HTTP methods supported by the route matching the request path,
+// when the request method is not allowed. It is meant for custom MethodNotAllowed handlers.
+func (ctx *Context) AllowedMethods() []string {
+	methods := make([]string, 0, len(ctx.methodsAllowed))
+	for _, mt := range ctx.methodsAllowed {
+		if m := methodTypeString(mt); m != "" {
+			methods = append(methods, m)
+		}
 	}
-	return p
+	sort.Strings(methods)
+
+	return methods
+}
+
+func (ctx *Context) addAllowedMethod(method methodType) {
+	for _, mt := range ctx.methodsAllowed {
+		if mt == method {
+			return
+		}
+	}
+	ctx.methodsAllowed = append(ctx.methodsAllowed, method)
+}
+
+// RouteMeta returns the metadata of the route matching the request,
+// merged with the metadata of the mounts the request was routed through, the innermost values taking precedence.
+// Like RoutePattern, the value changes throughout the request execution time in the router.
+// The returned Meta must not be modified.
+func (ctx *Context) RouteMeta() Meta {
+	return mergeMeta(ctx.routeMetas...)
 }
 
 // RouteContext returns gor routing Context object from a http.Request Context.
@@ -128,6 +260,14 @@ func URLParam(r *http.Request, key string) string {
 	return ""
 }
 
+// URLParamOk returns the url parameter from a http.Request object and whether it is present.
+func URLParamOk(r *http.Request, key string) (string, bool) {
+	if rctx := RouteContext(r.Context()); rctx != nil {
+		return rctx.URLParamOk(key)
+	}
+	return "", false
+}
+
 // URLParamFromCtx returns the url parameter from a http.Request Context.
 func URLParamFromCtx(ctx context.Context, key string) string {
 	if rctx := RouteContext(ctx); rctx != nil {
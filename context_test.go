@@ -1,6 +1,14 @@
 package gor
 
-import "testing"
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
 
 // TestRoutePattern tests correct in-the-middle wildcard removals.
 func TestRoutePattern(t *testing.T) {
@@ -60,3 +68,111 @@ func TestRoutePattern(t *testing.T) {
 		t.Fatal("unexpected route pattern: " + p)
 	}
 }
+
+func TestContextAsContext(t *testing.T) {
+	type key struct{}
+
+	deadline := time.Now().Add(time.Hour)
+	parent, cancel := context.WithDeadline(context.WithValue(context.Background(), key{}, "value"), deadline)
+
+	rctx := NewRouteContext()
+	rctx.parentCtx = parent
+
+	if rctx.Value(RouteCtxKey) != rctx || RouteContext(rctx) != rctx {
+		t.Fatal("expecting the routing context for RouteCtxKey")
+	}
+	if rctx.Value(key{}) != "value" {
+		t.Fatalf("expecting the parent value, got %v", rctx.Value(key{}))
+	}
+	if d, ok := rctx.Deadline(); !ok || !d.Equal(deadline) {
+		t.Fatalf("expecting the parent deadline, got %v", d)
+	}
+
+	// the contexts derived from the routing context are canceled with its parent
+	child, cancelChild := context.WithCancel(rctx)
+	defer cancelChild()
+
+	cancel()
+	<-child.Done()
+	if rctx.Err() != context.Canceled || child.Err() != context.Canceled {
+		t.Fatalf("expecting the context to be canceled, got %v", rctx.Err())
+	}
+
+	// a standalone routing context has no deadline and is never canceled
+	rctx = NewRouteContext()
+	if _, ok := rctx.Deadline(); ok || rctx.Done() != nil || rctx.Err() != nil || rctx.Value(key{}) != nil {
+		t.Fatal("unexpected standalone routing context")
+	}
+}
+
+func TestServeHTTPRequestContext(t *testing.T) {
+	type key struct{}
+
+	r := NewRouter()
+	r.Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		// the routing context is the request context, wrapping the context of the incoming request
+		rctx, ok := r.Context().(*Context)
+		if !ok || RouteContext(r.Context()) != rctx || r.Context().Value(key{}) != "value" {
+			t.Error("unexpected request context")
+		}
+		w.Write([]byte(rctx.URLParam("id")))
+	})
+
+	req := httptest.NewRequest("GET", "/users/1", nil)
+	req = req.WithContext(context.WithValue(req.Context(), key{}, "value"))
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	if w.Body.String() != "1" {
+		t.Fatalf("unexpected body %q", w.Body.String())
+	}
+}
+
+func TestServeHTTPPooledContext(t *testing.T) {
+	var got []string
+
+	r := NewRouter()
+	h := func(w http.ResponseWriter, r *http.Request) {
+		rctx := RouteContext(r.Context())
+		got = append(got, fmt.Sprintf("%v %v %v", rctx.URLParams.Keys, rctx.URLParams.Values, rctx.RoutePatterns))
+	}
+	r.Get("/users/{id}", h)
+	r.Get("/ping", h)
+
+	for _, path := range []string{"/users/1", "/ping", "/users/7"} {
+		req := httptest.NewRequest("GET", path, nil)
+		ctx := req.Context()
+		r.ServeHTTP(httptest.NewRecorder(), req)
+
+		// the handlers are served a copy of the request
+		if req.Context() != ctx {
+			t.Fatalf("%s: the context of the request is changed", path)
+		}
+	}
+
+	// the pooled routing context is reset between the requests
+	want := []string{"[id] [1] [/users/{id}]", "[] [] [/ping]", "[id] [7] [/users/{id}]"}
+	if strings.Join(got, ", ") != strings.Join(want, ", ") {
+		t.Fatalf("expecting %q, got %q", want, got)
+	}
+}
+
+func TestServeHTTPDerivedContext(t *testing.T) {
+	var derived []context.Context
+
+	r := NewRouter()
+	r.Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		derived = append(derived, ctx)
+	})
+
+	for _, path := range []string{"/users/1", "/users/7"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	// the routing context a context is derived from is not reused by the next requests
+	if id := URLParamFromCtx(derived[0], "id"); id != "1" {
+		t.Fatalf("expecting the id of the first request, got %q", id)
+	}
+}
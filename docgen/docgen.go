@@ -0,0 +1,86 @@
+// Package docgen generates the documentation of the routes of a gor router in Markdown and JSON,
+// describing the handlers and middlewares of each route with their doc comments.
+package docgen
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/pchchv/gor"
+)
+
+// Doc is the documentation of the routes of a router.
+type Doc struct {
+	Routes []Route `json:"routes"`
+}
+
+// Route is the documentation of a method and pattern of a router.
+type Route struct {
+	// Method is the HTTP method of the route.
+	Method string `json:"method"`
+
+	// Pattern is the full routing pattern of the route, including the host pattern and the mount patterns.
+	Pattern string `json:"pattern"`
+
+	// Handler describes the endpoint handler of the route.
+	Handler FuncInfo `json:"handler"`
+
+	// Middlewares describe the middleware stack the route is served through, in order.
+	Middlewares []FuncInfo `json:"middlewares,omitempty"`
+
+	// Predicates describe the request conditions of the route, see gor.Mux.Where.
+	Predicates []string `json:"predicates,omitempty"`
+
+	// Meta is the metadata of the route, see gor.Meta.
+	Meta gor.Meta `json:"meta,omitempty"`
+}
+
+// BuildDoc walks the router and its sub-routers to document their routes,
+// sorted by pattern and method.
+func BuildDoc(r gor.Routes) (Doc, error) {
+	doc := Doc{Routes: []Route{}}
+
+	err := gor.WalkRoutes(r, func(rt gor.RouteInfo) error {
+		dr := Route{
+			Method:  rt.Method,
+			Pattern: rt.Host + rt.Pattern,
+			Handler: GetFuncInfo(rt.Handler),
+			Meta:    rt.Meta,
+		}
+		for _, mw := range rt.Middlewares {
+			dr.Middlewares = append(dr.Middlewares, GetFuncInfo(mw))
+		}
+		for _, p := range rt.Predicates {
+			dr.Predicates = append(dr.Predicates, p.String())
+		}
+		doc.Routes = append(doc.Routes, dr)
+		return nil
+	})
+	if err != nil {
+		return Doc{}, err
+	}
+
+	// the variants of a route keep their order, see gor.Mux.Where
+	sort.SliceStable(doc.Routes, func(i, j int) bool {
+		if doc.Routes[i].Pattern != doc.Routes[j].Pattern {
+			return doc.Routes[i].Pattern < doc.Routes[j].Pattern
+		}
+		return doc.Routes[i].Method < doc.Routes[j].Method
+	})
+
+	return doc, nil
+}
+
+// JSONRoutesDoc returns the documentation of the routes of the router as indented JSON.
+func JSONRoutesDoc(r gor.Routes) (string, error) {
+	doc, err := BuildDoc(r)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
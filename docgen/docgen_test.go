@@ -0,0 +1,116 @@
+package docgen
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pchchv/gor"
+	"github.com/pchchv/gor/docgen/internal/testapi"
+	"github.com/pchchv/gor/middleware"
+)
+
+func testRouter() *gor.Mux {
+	users := &testapi.Users{}
+
+	r := gor.NewRouter()
+	r.Use(middleware.Logger)
+	r.Get("/ping", testapi.Routes())
+	r.Route("/users", func(r gor.Router) {
+		r.Use(testapi.Auth)
+		r.Get("/", users.List)
+		r.Get("/{id}", testapi.GetUser)
+	})
+	r.Handle("/metrics", http.NotFoundHandler())
+
+	return r
+}
+
+func TestGetFuncInfo(t *testing.T) {
+	fi := GetFuncInfo(testapi.GetUser)
+	if fi.Pkg != "github.com/pchchv/gor/docgen/internal/testapi" || fi.Func != "GetUser" || fi.Anonymous || fi.Unresolvable {
+		t.Fatalf("unexpected func info %+v", fi)
+	}
+	if fi.Comment != "GetUser returns the user {id}.\n\nIt replies 404 when there is no such user." {
+		t.Fatalf("unexpected comment %q", fi.Comment)
+	}
+	if filepath.Base(fi.File) != "testapi.go" || fi.Line != 15 {
+		t.Fatalf("unexpected location %s:%d", fi.File, fi.Line)
+	}
+
+	if fi = GetFuncInfo((&testapi.Users{}).List); fi.Func != "(*Users).List" || fi.Comment != "List lists the users." {
+		t.Fatalf("unexpected method info %+v", fi)
+	}
+
+	if fi = GetFuncInfo(testapi.Routes()); !fi.Anonymous || fi.Func != "Routes.func1" || fi.Comment != "Ping replies pong." {
+		t.Fatalf("unexpected anonymous func info %+v", fi)
+	}
+
+	if fi = GetFuncInfo(http.NotFoundHandler()); fi.Unresolvable || fi.String() != "net/http.NotFound" {
+		t.Fatalf("unexpected handler info %+v", fi)
+	}
+
+	if fi = GetFuncInfo(gor.NewRouter()); !fi.Unresolvable || fi.String() != "*gor.Mux" {
+		t.Fatalf("unexpected handler info %+v", fi)
+	}
+}
+
+func TestJSONRoutesDoc(t *testing.T) {
+	js, err := JSONRoutesDoc(testRouter())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc Doc
+	if err := json.Unmarshal([]byte(js), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	var routes []string
+	for _, rt := range doc.Routes {
+		routes = append(routes, rt.Method+" "+rt.Pattern)
+	}
+	// the /metrics route is documented for each method
+	if len(routes) != 12 || routes[0] != "CONNECT /metrics" || routes[9] != "GET /ping" || routes[10] != "GET /users/" || routes[11] != "GET /users/{id}" {
+		t.Fatalf("unexpected routes %v", routes)
+	}
+
+	rt := doc.Routes[len(doc.Routes)-1]
+	if rt.Handler.Func != "GetUser" || len(rt.Middlewares) != 2 || rt.Middlewares[0].Func != "Logger" || rt.Middlewares[1].Comment != "Auth rejects the requests without credentials." {
+		t.Fatalf("unexpected route %+v", rt)
+	}
+	if !strings.HasPrefix(rt.Middlewares[0].Comment, "Logger is the middleware") {
+		t.Fatalf("unexpected middleware comment %q", rt.Middlewares[0].Comment)
+	}
+}
+
+func TestMarkdownRoutesDoc(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	md, err := MarkdownRoutesDoc(testRouter(), MarkdownOpts{
+		Intro:       "The test API.",
+		ProjectPath: filepath.Dir(wd),
+		SourceURL:   "https://github.com/pchchv/gor/blob/main",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, s := range []string{
+		"# Routes\n\nThe test API.\n\n",
+		"## `GET /users/{id}`\n\nGetUser returns the user {id}.\n\nIt replies 404 when there is no such user.\n\n",
+		"- handler: `github.com/pchchv/gor/docgen/internal/testapi.GetUser` ([docgen/internal/testapi/testapi.go:15](https://github.com/pchchv/gor/blob/main/docgen/internal/testapi/testapi.go#L15))\n",
+		"  - `github.com/pchchv/gor/docgen/internal/testapi.Auth` ([docgen/internal/testapi/testapi.go:18](https://github.com/pchchv/gor/blob/main/docgen/internal/testapi/testapi.go#L18)): Auth rejects the requests without credentials.\n",
+		"## `GET /ping`\n\nPing replies pong.\n\n",
+	} {
+		if !strings.Contains(md, s) {
+			t.Fatalf("expecting %q in\n%s", s, md)
+		}
+	}
+}
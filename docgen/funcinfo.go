@@ -0,0 +1,252 @@
+package docgen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/doc"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// FuncInfo describes a handler or a middleware function.
+type FuncInfo struct {
+	// Pkg is the import path of the package of the function, e.g. "github.com/pchchv/gor/middleware".
+	Pkg string `json:"pkg"`
+
+	// Func is the name of the function in its package, e.g. "Logger", "(*Server).listUsers" or "main.func1".
+	Func string `json:"func"`
+
+	// File and Line locate the function in its source file.
+	File string `json:"file,omitempty"`
+	Line int    `json:"line,omitempty"`
+
+	// Comment is the doc comment of the function, or the comment above an anonymous function.
+	Comment string `json:"comment,omitempty"`
+
+	// Anonymous reports whether the function is a function literal.
+	Anonymous bool `json:"anonymous,omitempty"`
+
+	// Unresolvable reports whether the value is not a function, e.g. a http.Handler of another type,
+	// in which case Func is the type of the value.
+	Unresolvable bool `json:"unresolvable,omitempty"`
+}
+
+// String returns the name of the function qualified by its package.
+func (fi FuncInfo) String() string {
+	if fi.Unresolvable {
+		return fi.Func
+	}
+	return fi.Pkg + "." + fi.Func
+}
+
+// GetFuncInfo describes the function of a http.HandlerFunc or a middleware, or else the type of the value.
+// The doc comments are read from the source files of the package of the function, when they are available.
+func GetFuncInfo(i interface{}) FuncInfo {
+	fi := FuncInfo{}
+
+	rv := reflect.ValueOf(i)
+	if rv.Kind() != reflect.Func || rv.IsNil() {
+		fi.Func = fmt.Sprintf("%T", i)
+		fi.Unresolvable = true
+		return fi
+	}
+
+	pc := rv.Pointer()
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		fi.Func = fmt.Sprintf("%T", i)
+		fi.Unresolvable = true
+		return fi
+	}
+
+	fi.Pkg, fi.Func = splitFuncName(fn.Name())
+	fi.Anonymous = isAnonymous(fi.Func)
+	fi.File, fi.Line = fn.FileLine(pc)
+
+	// method values are wrapped by generated functions, e.g. "(*T).Name-fm",
+	// so the method is located in the source of its package
+	if name, ok := strings.CutSuffix(fi.Func, "-fm"); ok {
+		fi.Func = name
+		fi.File, fi.Line = locateMethod(fi.Pkg, fi.Func)
+	}
+
+	fi.Comment = funcComment(fi)
+
+	return fi
+}
+
+// splitFuncName splits a qualified function name, e.g. "github.com/pchchv/gor/middleware.Logger".
+func splitFuncName(name string) (string, string) {
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return "", name
+	}
+	dot += slash + 1
+	return name[:dot], name[dot+1:]
+}
+
+// isAnonymous reports whether a function name is the one of a function literal, e.g. "main.func1" or "New.func2.1".
+func isAnonymous(name string) bool {
+	for _, part := range strings.Split(name, ".") {
+		if strings.HasPrefix(part, "func") && len(part) > 4 && part[4] >= '0' && part[4] <= '9' {
+			return true
+		}
+	}
+	return false
+}
+
+// funcComment returns the doc comment of a function, or the comment right above a function literal.
+func funcComment(fi FuncInfo) string {
+	src := loadSource(filepath.Dir(fi.File))
+	if src == nil {
+		return ""
+	}
+
+	f := src.files[fi.File]
+	if f == nil {
+		return ""
+	}
+
+	if fi.Anonymous {
+		for _, cg := range f.Comments {
+			if src.fset.Position(cg.End()).Line == fi.Line-1 {
+				return strings.TrimSpace(cg.Text())
+			}
+		}
+		return ""
+	}
+
+	pkg := src.pkgs[f.Name.Name]
+	if pkg == nil {
+		return ""
+	}
+
+	// the methods are named "(*T).Name" or "T.Name"
+	recv, name, isMethod := strings.Cut(fi.Func, ".")
+	if !isMethod {
+		for _, f := range pkg.Funcs {
+			if f.Name == fi.Func {
+				return strings.TrimSpace(f.Doc)
+			}
+		}
+	}
+
+	recv = strings.TrimSuffix(strings.TrimPrefix(recv, "(*"), ")")
+	for _, t := range pkg.Types {
+		if !isMethod {
+			for _, f := range t.Funcs {
+				if f.Name == fi.Func {
+					return strings.TrimSpace(f.Doc)
+				}
+			}
+			continue
+		}
+
+		if t.Name != recv {
+			continue
+		}
+		for _, m := range t.Methods {
+			if m.Name == name {
+				return strings.TrimSpace(m.Doc)
+			}
+		}
+	}
+
+	return ""
+}
+
+// locateMethod returns the source location of a method of a package, or an empty file if it is not found.
+func locateMethod(pkgPath, name string) (string, int) {
+	bp, err := build.Import(pkgPath, ".", build.FindOnly)
+	if err != nil {
+		return "", 0
+	}
+
+	src := loadSource(bp.Dir)
+	if src == nil {
+		return "", 0
+	}
+
+	recv, method, _ := strings.Cut(name, ".")
+	recv = strings.TrimSuffix(strings.TrimPrefix(recv, "(*"), ")")
+	for filename, f := range src.files {
+		for _, decl := range f.Decls {
+			fd, ok := decl.(*ast.FuncDecl)
+			if !ok || fd.Recv == nil || len(fd.Recv.List) == 0 || fd.Name.Name != method {
+				continue
+			}
+
+			typ := fd.Recv.List[0].Type
+			if star, ok := typ.(*ast.StarExpr); ok {
+				typ = star.X
+			}
+			if id, ok := typ.(*ast.Ident); ok && id.Name == recv {
+				return filename, src.fset.Position(fd.Pos()).Line
+			}
+		}
+	}
+
+	return "", 0
+}
+
+// source is the parsed source of the packages of a directory.
+type source struct {
+	fset  *token.FileSet
+	pkgs  map[string]*doc.Package
+	files map[string]*ast.File
+}
+
+var (
+	sourcesMu sync.Mutex
+	sources   = map[string]*source{}
+)
+
+// loadSource parses the source files of the package in the directory, or returns nil if they are not available.
+func loadSource(dir string) *source {
+	sourcesMu.Lock()
+	defer sourcesMu.Unlock()
+
+	if src, ok := sources[dir]; ok {
+		return src
+	}
+
+	src := parseSource(dir)
+	sources[dir] = src
+	return src
+}
+
+func parseSource(dir string) *source {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi fs.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.ParseComments)
+	if err != nil || len(pkgs) == 0 {
+		return nil
+	}
+
+	src := &source{fset: fset, pkgs: make(map[string]*doc.Package), files: make(map[string]*ast.File)}
+	for name, p := range pkgs {
+		files := make([]*ast.File, 0, len(p.Files))
+		for filename, f := range p.Files {
+			files = append(files, f)
+			src.files[filename] = f
+		}
+
+		pkg, err := doc.NewFromFiles(fset, files, path.Base(filepath.ToSlash(dir)), doc.AllDecls|doc.PreserveAST)
+		if err != nil {
+			continue
+		}
+		src.pkgs[name] = pkg
+	}
+
+	return src
+}
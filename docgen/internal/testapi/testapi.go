@@ -0,0 +1,28 @@
+// Package testapi holds documented handlers for the docgen tests.
+package testapi
+
+import "net/http"
+
+// Users serves the users of the API.
+type Users struct{}
+
+// List lists the users.
+func (u *Users) List(w http.ResponseWriter, r *http.Request) {}
+
+// GetUser returns the user {id}.
+//
+// It replies 404 when there is no such user.
+func GetUser(w http.ResponseWriter, r *http.Request) {}
+
+// Auth rejects the requests without credentials.
+func Auth(next http.Handler) http.Handler {
+	return next
+}
+
+// Routes returns an anonymous handler.
+func Routes() http.HandlerFunc {
+	// Ping replies pong.
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("pong"))
+	}
+}
@@ -0,0 +1,97 @@
+package docgen
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/pchchv/gor"
+)
+
+// MarkdownOpts configures the Markdown documentation.
+type MarkdownOpts struct {
+	// Title of the document, "Routes" by default.
+	Title string
+
+	// Intro is the text following the title.
+	Intro string
+
+	// ProjectPath is the directory of the project, trimmed from the source file paths.
+	ProjectPath string
+
+	// SourceURL, if set, links the source locations relative to ProjectPath,
+	// e.g. "https://github.com/pchchv/gor/blob/main" links "mux.go:42" to ".../blob/main/mux.go#L42".
+	SourceURL string
+}
+
+// MarkdownRoutesDoc returns the documentation of the routes of the router in Markdown,
+// a section per route listing its handler and its middlewares along with their doc comments.
+func MarkdownRoutesDoc(r gor.Routes, opts MarkdownOpts) (string, error) {
+	doc, err := BuildDoc(r)
+	if err != nil {
+		return "", err
+	}
+
+	title := opts.Title
+	if title == "" {
+		title = "Routes"
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	if opts.Intro != "" {
+		fmt.Fprintf(&b, "%s\n\n", strings.TrimSpace(opts.Intro))
+	}
+
+	for _, rt := range doc.Routes {
+		fmt.Fprintf(&b, "## `%s %s`\n\n", rt.Method, rt.Pattern)
+		if len(rt.Predicates) > 0 {
+			fmt.Fprintf(&b, "Only for the requests matching: %s.\n\n", strings.Join(rt.Predicates, ", "))
+		}
+		if rt.Handler.Comment != "" {
+			fmt.Fprintf(&b, "%s\n\n", rt.Handler.Comment)
+		}
+
+		fmt.Fprintf(&b, "- handler: %s\n", opts.funcRef(rt.Handler))
+		if len(rt.Middlewares) > 0 {
+			b.WriteString("- middlewares:\n")
+			for _, mw := range rt.Middlewares {
+				fmt.Fprintf(&b, "  - %s", opts.funcRef(mw))
+				if mw.Comment != "" {
+					fmt.Fprintf(&b, ": %s", firstLine(mw.Comment))
+				}
+				b.WriteString("\n")
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String(), nil
+}
+
+// funcRef formats the name of a function along with its source location.
+func (opts MarkdownOpts) funcRef(fi FuncInfo) string {
+	ref := "`" + fi.String() + "`"
+	if fi.File == "" {
+		return ref
+	}
+
+	file := filepath.ToSlash(fi.File)
+	if opts.ProjectPath != "" {
+		if rel, err := filepath.Rel(opts.ProjectPath, fi.File); err == nil && !strings.HasPrefix(rel, "..") {
+			file = filepath.ToSlash(rel)
+		}
+	}
+
+	loc := fmt.Sprintf("%s:%d", file, fi.Line)
+	if opts.SourceURL != "" && !filepath.IsAbs(file) {
+		return fmt.Sprintf("%s ([%s](%s/%s#L%d))", ref, loc, strings.TrimSuffix(opts.SourceURL, "/"), file, fi.Line)
+	}
+	return fmt.Sprintf("%s (%s)", ref, loc)
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
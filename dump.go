@@ -0,0 +1,212 @@
+package gor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// DumpFormat is the output format of DumpRoutes.
+type DumpFormat uint8
+
+const (
+	// DumpText is a table aligned in columns of the methods, the full routing patterns,
+	// the handlers and the middlewares of the routes.
+	DumpText DumpFormat = iota
+
+	// DumpJSON is a JSON array of the routes, with the same details as DumpText.
+	DumpJSON
+
+	// DumpDOT is a Graphviz DOT graph of the routing trees of the routers,
+	// each node being labeled with the segment of the routing patterns it matches and its type.
+	DumpDOT
+)
+
+// DumpedRoute describes a route in the DumpJSON format.
+type DumpedRoute struct {
+	Method      string   `json:"method"`
+	Pattern     string   `json:"pattern"`
+	Handler     string   `json:"handler"`
+	Middlewares []string `json:"middlewares,omitempty"`
+	Predicates  []string `json:"predicates,omitempty"`
+}
+
+// DumpRoutes describes the routes of a router and of its sub-routers in the format, for debugging.
+// The routes are sorted by pattern and method, so that the output of the same routes is stable.
+// Handlers and middlewares are named after their function, or their type for the other handlers.
+func DumpRoutes(r Routes, format DumpFormat) (string, error) {
+	switch format {
+	case DumpText, DumpJSON:
+	case DumpDOT:
+		return dumpDOT(r), nil
+	default:
+		return "", fmt.Errorf("gor: unknown dump format %d", format)
+	}
+
+	routes, err := dumpedRoutes(r)
+	if err != nil {
+		return "", err
+	}
+
+	if format == DumpJSON {
+		b, err := json.MarshalIndent(routes, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(b) + "\n", nil
+	}
+
+	var b bytes.Buffer
+	tw := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tPATTERN\tHANDLER\tMIDDLEWARES")
+	for _, rt := range routes {
+		pattern := rt.Pattern
+		if len(rt.Predicates) > 0 {
+			pattern += " [" + strings.Join(rt.Predicates, ", ") + "]"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", rt.Method, pattern, rt.Handler, strings.Join(rt.Middlewares, ", "))
+	}
+	if err := tw.Flush(); err != nil {
+		return "", err
+	}
+
+	// trim the padding of the routes without middlewares
+	lines := strings.SplitAfter(b.String(), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " \n")
+		if l != lines[i] {
+			lines[i] += "\n"
+		}
+	}
+	return strings.Join(lines, ""), nil
+}
+
+func dumpedRoutes(r Routes) ([]DumpedRoute, error) {
+	var routes []DumpedRoute
+
+	err := WalkRoutes(r, func(rt RouteInfo) error {
+		dr := DumpedRoute{Method: rt.Method, Pattern: rt.Host + rt.Pattern, Handler: funcName(rt.Handler)}
+		for _, mw := range rt.Middlewares {
+			dr.Middlewares = append(dr.Middlewares, funcName(mw))
+		}
+		for _, p := range rt.Predicates {
+			dr.Predicates = append(dr.Predicates, p.String())
+		}
+		routes = append(routes, dr)
+		return nil
+	})
+
+	// the variants of a route keep their order, see Where
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Pattern != routes[j].Pattern {
+			return routes[i].Pattern < routes[j].Pattern
+		}
+		return routes[i].Method < routes[j].Method
+	})
+
+	return routes, err
+}
+
+// funcName returns the name of a function, of the function of an http.HandlerFunc, or else the type of the value.
+func funcName(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Func && !rv.IsNil() {
+		if fn := runtime.FuncForPC(rv.Pointer()); fn != nil {
+			return fn.Name()
+		}
+	}
+	return fmt.Sprintf("%T", v)
+}
+
+// dotGraph renders the routing trees of the routers as a DOT graph.
+type dotGraph struct {
+	b   bytes.Buffer
+	ids int
+}
+
+func dumpDOT(r Routes) string {
+	g := &dotGraph{}
+	g.b.WriteString("digraph routes {\n\tnode [shape=box, fontname=monospace];\n")
+	g.routes(r)
+	g.b.WriteString("}\n")
+	return g.b.String()
+}
+
+// routes renders the routing tree of a Mux, or else a node for the router linked to its sub-routers,
+// returning the id of the root node.
+func (g *dotGraph) routes(r Routes) string {
+	if mx, ok := r.(*Mux); ok {
+		return g.node(mx.tree.load())
+	}
+
+	id := g.id()
+	fmt.Fprintf(&g.b, "\t%s [label=%q, shape=component];\n", id, fmt.Sprintf("%T", r))
+	for _, rt := range r.Routes() {
+		if rt.SubRoutes == nil {
+			continue
+		}
+		sub := g.routes(rt.SubRoutes)
+		fmt.Fprintf(&g.b, "\t%s -> %s [label=%q, style=dashed];\n", id, sub, rt.Host+rt.Pattern)
+	}
+	return id
+}
+
+// node renders a tree node and its children, returning the id of the node.
+func (g *dotGraph) node(n *node) string {
+	id := g.id()
+
+	segment := n.segment()
+	if n.ntype == ntStatic && segment == "" {
+		segment = "(root)"
+	}
+	label := segment + "\n" + string(nodeStepKinds[n.ntype])
+	if methods := endpointMethods(n.endpoints); len(methods) > 0 {
+		label += "\n" + strings.Join(methods, " ")
+	}
+
+	style := ""
+	if len(n.endpoints) > 0 {
+		style = ", style=bold"
+	}
+	fmt.Fprintf(&g.b, "\t%s [label=%q%s];\n", id, label, style)
+
+	for _, nds := range n.child {
+		for _, cn := range nds {
+			fmt.Fprintf(&g.b, "\t%s -> %s;\n", id, g.node(cn))
+		}
+	}
+
+	if n.subroutes != nil {
+		fmt.Fprintf(&g.b, "\t%s -> %s [label=\"mount\", style=dashed];\n", id, g.routes(n.subroutes))
+	}
+
+	return id
+}
+
+func (g *dotGraph) id() string {
+	g.ids++
+	return fmt.Sprintf("n%d", g.ids)
+}
+
+// endpointMethods returns the sorted methods of the endpoints having a handler.
+func endpointMethods(eps endpoints) []string {
+	var methods []string
+	for mt, h := range eps {
+		if h.handler == nil || mt == mSTUB || mt == mALL {
+			continue
+		}
+		if m := methodTypeString(mt); m != "" {
+			methods = append(methods, m)
+		}
+	}
+	if h := eps[mALL]; h != nil && h.handler != nil {
+		return []string{"*"}
+	}
+	sort.Strings(methods)
+	return methods
+}
@@ -0,0 +1,88 @@
+package gor
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func dumpListUsers(w http.ResponseWriter, r *http.Request) {}
+func dumpGetUser(w http.ResponseWriter, r *http.Request)   {}
+
+func dumpMiddleware(next http.Handler) http.Handler { return next }
+
+func TestDumpRoutes(t *testing.T) {
+	r := NewRouter()
+	r.Use(dumpMiddleware)
+	r.Get("/", dumpListUsers)
+	r.Route("/users", func(r Router) {
+		r.Get("/", dumpListUsers)
+		r.With(dumpMiddleware).Get("/{id:[0-9]+}", dumpGetUser)
+		r.Where(Query("format", "csv")).Get("/export/*", dumpListUsers)
+	})
+
+	text, err := DumpRoutes(r, DumpText)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `METHOD  PATTERN                                   HANDLER                           MIDDLEWARES
+GET     /                                         github.com/pchchv/gor.dumpListUsers  github.com/pchchv/gor.dumpMiddleware
+GET     /users/                                   github.com/pchchv/gor.dumpListUsers  github.com/pchchv/gor.dumpMiddleware
+GET     /users/export/* [query format=csv]        github.com/pchchv/gor.dumpListUsers  github.com/pchchv/gor.dumpMiddleware
+GET     /users/{id:[0-9]+}                        github.com/pchchv/gor.dumpGetUser    github.com/pchchv/gor.dumpMiddleware, github.com/pchchv/gor.dumpMiddleware
+`
+	if normalizeSpaces(text) != normalizeSpaces(expected) {
+		t.Fatalf("expecting\n%s\ngot\n%s", expected, text)
+	}
+
+	lines := strings.Split(text, "\n")
+	if col := strings.Index(lines[0], "HANDLER"); strings.Index(lines[1], "github.com") != col || strings.Index(lines[4], "github.com") != col {
+		t.Fatalf("expecting aligned columns, got\n%s", text)
+	}
+
+	js, err := DumpRoutes(r, DumpJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var routes []DumpedRoute
+	if err := json.Unmarshal([]byte(js), &routes); err != nil {
+		t.Fatal(err)
+	}
+	if len(routes) != 4 || routes[3].Pattern != "/users/{id:[0-9]+}" || len(routes[3].Middlewares) != 2 || routes[2].Predicates[0] != "query format=csv" {
+		t.Fatalf("unexpected routes %s", js)
+	}
+	if again, _ := DumpRoutes(r, DumpJSON); again != js {
+		t.Fatal("expecting a stable JSON dump")
+	}
+
+	dot, err := DumpRoutes(r, DumpDOT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		"digraph routes {",
+		`[label="(root)\nstatic"`,
+		`[label="/\nstatic\nGET", style=bold]`,
+		`[label="mount", style=dashed]`,
+		`[label="{:^[0-9]+$}\nregexp\nGET", style=bold]`,
+		`[label="*\ncatch-all\nGET", style=bold]`,
+	} {
+		if !strings.Contains(dot, s) {
+			t.Fatalf("expecting %q in the DOT graph\n%s", s, dot)
+		}
+	}
+
+	if _, err := DumpRoutes(r, DumpFormat(9)); err == nil {
+		t.Fatal("expecting an error for an unknown format")
+	}
+}
+
+func normalizeSpaces(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.Join(strings.Fields(l), " ")
+	}
+	return strings.Join(lines, "\n")
+}
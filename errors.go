@@ -0,0 +1,59 @@
+package gor
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kinds of route registration problems, wrapped by the *RouteError returned
+// by the Try* methods of Mux, by Builder and by the route removal methods of Mux.
+// Registering a route with one of these problems through the other methods of Mux panics with the *RouteError.
+var (
+	ErrInvalidPattern    = errors.New("invalid routing pattern")
+	ErrDuplicateParam    = errors.New("duplicate param key")
+	ErrDuplicateName     = errors.New("duplicate route name")
+	ErrMountConflict     = errors.New("mount on an existing path")
+	ErrUnsupportedMethod = errors.New("unsupported http method")
+	ErrMiddlewareOrder   = errors.New("middleware defined after routes")
+	ErrNilHandler        = errors.New("nil handler")
+	ErrRouteNotFound     = errors.New("route not found")
+)
+
+// RouteError describes a problem with the registration of a route.
+type RouteError struct {
+	// Err is the kind of the problem, one of the Err* errors.
+	Err error
+
+	// Method is the HTTP method of the route, if any.
+	Method string
+
+	// Pattern is the routing pattern of the route, if any.
+	Pattern string
+
+	// Pos is the offset of the offending part of Pattern for invalid patterns and duplicate params, -1 otherwise.
+	Pos int
+
+	// msg describes the problem
+	msg string
+}
+
+func (e *RouteError) Error() string {
+	if e.Pos >= 0 {
+		return fmt.Sprintf("gor: %s, at position %d of routing pattern '%s'", e.msg, e.Pos, e.Pattern)
+	}
+	return "gor: " + e.msg
+}
+
+func (e *RouteError) Unwrap() error {
+	return e.Err
+}
+
+// patternError returns a *RouteError of the kind `err` located at `pos` in a routing pattern.
+func patternError(err error, pattern string, pos int, format string, args ...interface{}) *RouteError {
+	return &RouteError{Err: err, Pattern: pattern, Pos: pos, msg: fmt.Sprintf(format, args...)}
+}
+
+// routeError returns a *RouteError of the kind `err` which is not located in a routing pattern.
+func routeError(err error, method, pattern string, format string, args ...interface{}) *RouteError {
+	return &RouteError{Err: err, Method: method, Pattern: pattern, Pos: -1, msg: fmt.Sprintf(format, args...)}
+}
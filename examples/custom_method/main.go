@@ -7,14 +7,11 @@ import (
 	"github.com/pchchv/gor/middleware"
 )
 
-func init() {
-	gor.RegisterMethod("LINK")
-	gor.RegisterMethod("UNLINK")
-	gor.RegisterMethod("WOOHOO")
-}
-
 func main() {
 	r := gor.NewRouter()
+	r.RegisterMethod("LINK")
+	r.RegisterMethod("UNLINK")
+	r.RegisterMethod("WOOHOO")
 	r.Use(middleware.RequestID)
 	r.Use(middleware.Logger)
 	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
@@ -38,4 +38,11 @@ func main() {
 	if err := gor.Walk(r, walkFunc); err != nil {
 		fmt.Printf("Logging err: %s\n", err.Error())
 	}
+
+	// print the route table along with the handler and middleware names
+	table, err := gor.DumpRoutes(r, gor.DumpText)
+	if err != nil {
+		fmt.Printf("Logging err: %s\n", err.Error())
+	}
+	fmt.Print(table)
 }
@@ -0,0 +1,219 @@
+package gor
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// StepKind is the kind of a step of an Explanation.
+type StepKind string
+
+const (
+	// StepStatic, StepRegexp, StepParam and StepCatchAll are the lookups of
+	// the static, regexp, param and wildcard nodes of a routing tree.
+	StepStatic   StepKind = "static"
+	StepRegexp   StepKind = "regexp"
+	StepParam    StepKind = "param"
+	StepCatchAll StepKind = "catch-all"
+
+	// StepEndpoint is the lookup of the handler of the request method on a node matching the whole path.
+	StepEndpoint StepKind = "endpoint"
+
+	// StepRoutePath is a rewrite of the routing path by the path matching options of a router.
+	StepRoutePath StepKind = "route-path"
+
+	// StepMount is the hop from a router to the sub-router mounted on the matching route.
+	StepMount StepKind = "mount"
+)
+
+var nodeStepKinds = [...]StepKind{ntStatic: StepStatic, ntRegexp: StepRegexp, ntParam: StepParam, ntCatchAll: StepCatchAll}
+
+// Explanation is the trace of the route lookup of a request method and path, as returned by Mux.Explain.
+type Explanation struct {
+	// Method and Path are the explained request method and path.
+	Method string
+	Path   string
+
+	// Steps taken by the routers in order, including the rejected candidates.
+	Steps []ExplainStep
+
+	// Status is the status of the response the routers would serve: 200 when a handler matches,
+	// 204 for automatic OPTIONS responses, 301 or 308 for trailing slash redirects, 404 or 405.
+	Status int
+
+	// Pattern is the routing pattern of the matching route, see Context.RoutePattern.
+	Pattern string
+
+	// Params are the URL params of the matching route.
+	Params RouteParams
+
+	// AllowedMethods are the methods supported by the route found for a method not allowed.
+	AllowedMethods []string
+
+	// Redirect is the routing path the request would be redirected to by the trailing slash policy.
+	Redirect string
+
+	// depth of the current router in the stack of mounted sub-routers
+	depth int
+}
+
+// ExplainStep is a step of the route lookup described by an Explanation.
+type ExplainStep struct {
+	// Depth of the router in the stack of mounted sub-routers, 0 for the explained Mux.
+	Depth int
+
+	// Kind of the step.
+	Kind StepKind
+
+	// Node is the static prefix, the param or the wildcard of a tree node,
+	// the routing pattern of an endpoint or of a mount, or the rewritten routing path.
+	Node string
+
+	// Path is the routing path left to match.
+	Path string
+
+	// Matched reports whether the node, the endpoint or the mount matched.
+	Matched bool
+
+	// Reason describes why a candidate was rejected or details a matching step.
+	Reason string
+}
+
+// Explain looks the route of a request method and path up through the Mux and its mounted sub-routers
+// without serving it, returning the trace of the lookup for debugging. Host routers are not considered.
+func (mx *Mux) Explain(method, path string) *Explanation {
+	e := &Explanation{Method: method, Path: path}
+
+	rctx := NewRouteContext()
+	rctx.Routes = mx
+	rctx.trace = e
+	mx.explain(rctx, method, path)
+
+	e.Pattern = rctx.RoutePattern()
+	e.Params.Keys = append(e.Params.Keys, rctx.URLParams.Keys...)
+	e.Params.Values = append(e.Params.Values, rctx.URLParams.Values...)
+
+	return e
+}
+
+func (mx *Mux) explain(rctx *Context, method, path string) {
+	e := rctx.trace
+
+	m, ok := mx.methodType(method)
+	if !ok {
+		e.add(StepEndpoint, "", path, false, "method "+method+" is not supported")
+		e.Status = http.StatusMethodNotAllowed
+		return
+	}
+
+	n, h, redirectPath := mx.findRoute(rctx, m, path)
+	switch {
+	case n != nil && n.subroutes != nil && h != nil:
+		if subMux, ok := n.subroutes.(*Mux); ok {
+			rctx.RoutePath = mx.nextRoutePath(rctx, path)
+			e.mount(rctx.routePattern, rctx.RoutePath)
+			subMux.explain(rctx, method, rctx.RoutePath)
+			return
+		}
+		e.Status = http.StatusOK
+
+	case h != nil:
+		e.Status = http.StatusOK
+
+	case redirectPath != "":
+		e.Status = http.StatusPermanentRedirect
+		if m == mGET || m == mHEAD {
+			e.Status = http.StatusMovedPermanently
+		}
+		e.Redirect = redirectPath
+
+	case rctx.methodNotAllowed:
+		e.Status = http.StatusMethodNotAllowed
+		if mx.cfg != nil && mx.cfg.autoOptions {
+			rctx.addAllowedMethod(mOPTIONS)
+			if m == mOPTIONS {
+				e.Status = http.StatusNoContent
+			}
+		}
+		e.AllowedMethods = rctx.AllowedMethods()
+
+	default:
+		e.Status = http.StatusNotFound
+	}
+}
+
+// String formats the steps of the explanation, one per line, indented by the depth of their router.
+func (e *Explanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s: %d", e.Method, e.Path, e.Status)
+	for _, s := range e.Steps {
+		mark := "-"
+		if s.Matched {
+			mark = "+"
+		}
+		fmt.Fprintf(&b, "\n%s%s %s %q at %q", strings.Repeat("  ", s.Depth+1), mark, s.Kind, s.Node, s.Path)
+		if s.Reason != "" {
+			b.WriteString(": " + s.Reason)
+		}
+	}
+	return b.String()
+}
+
+// compact formats the steps of the explanation on a single line, for the trace header.
+func (e *Explanation) compact() string {
+	var b strings.Builder
+	for i, s := range e.Steps {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		if s.Matched {
+			b.WriteByte('+')
+		} else {
+			b.WriteByte('-')
+		}
+		b.WriteString(string(s.Kind) + ":" + s.Node)
+		if s.Reason != "" {
+			b.WriteString("(" + s.Reason + ")")
+		}
+	}
+	return b.String()
+}
+
+func (e *Explanation) add(kind StepKind, node, path string, matched bool, reason string) {
+	e.Steps = append(e.Steps, ExplainStep{Depth: e.depth, Kind: kind, Node: node, Path: path, Matched: matched, Reason: reason})
+}
+
+// node records the lookup of a tree node.
+func (e *Explanation) node(n *node, path string, matched bool, reason string) {
+	e.add(nodeStepKinds[n.ntype], n.segment(), path, matched, reason)
+}
+
+// endpoint records the lookup of the endpoint of the method on a node matching the whole path.
+func (e *Explanation) endpoint(n *node, method methodType, matched bool) {
+	m := methodTypeString(method)
+	if h := n.endpoints.get(method); matched {
+		e.add(StepEndpoint, h.pattern, "", true, m)
+		return
+	}
+
+	var pattern string
+	var allowed []string
+	for mt, h := range n.endpoints {
+		if mt == mSTUB || mt == mALL || h.handler == nil {
+			continue
+		}
+		pattern = h.pattern
+		allowed = append(allowed, methodTypeString(mt))
+	}
+	sort.Strings(allowed)
+
+	e.add(StepEndpoint, pattern, "", false, fmt.Sprintf("method %s not allowed, allowed: %s", m, strings.Join(allowed, ", ")))
+}
+
+// mount records the hop to a mounted sub-router.
+func (e *Explanation) mount(pattern, routePath string) {
+	e.add(StepMount, pattern, routePath, true, "")
+	e.depth++
+}
@@ -0,0 +1,105 @@
+package gor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMuxExplain(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := NewRouter(WithTrailingSlash(TrailingSlashRedirect))
+	r.Get("/users/{id:int}", h)
+	r.Post("/users/{name}", h)
+	r.Get("/files/*/meta", h)
+	r.Route("/api", func(r Router) {
+		r.Get("/items/{id}", h)
+	})
+
+	e := r.Explain("GET", "/api/items/42")
+	if e.Status != 200 || e.Pattern != "/api/items/{id}" || e.Params.Values[len(e.Params.Values)-1] != "42" {
+		t.Fatalf("unexpected explanation %s", e)
+	}
+
+	var kinds []string
+	for _, s := range e.Steps {
+		kinds = append(kinds, string(s.Kind))
+	}
+	if expected := "static static static catch-all endpoint mount static param endpoint"; strings.Join(kinds, " ") != expected {
+		t.Fatalf("expecting steps '%s', got '%s'\n%s", expected, strings.Join(kinds, " "), e)
+	}
+	if mount := e.Steps[5]; mount.Node != "/api/*" || mount.Path != "/items/42" || e.Steps[6].Depth != 1 {
+		t.Fatalf("unexpected mount step %+v", mount)
+	}
+
+	e = r.Explain("GET", "/users/abc")
+	if e.Status != 405 || strings.Join(e.AllowedMethods, ",") != "POST" {
+		t.Fatalf("unexpected explanation %s", e)
+	}
+	if !hasStep(e, StepRegexp, false, "value 'abc' does not match") ||
+		!hasStep(e, StepEndpoint, false, "method GET not allowed, allowed: POST") {
+		t.Fatalf("expecting the rejected regexp and method in the steps\n%s", e)
+	}
+
+	e = r.Explain("GET", "/files/a/b/meta")
+	if e.Status != 200 || e.Pattern != "/files/*/meta" || !hasStep(e, StepCatchAll, true, "value 'a/b'") {
+		t.Fatalf("unexpected explanation %s", e)
+	}
+
+	e = r.Explain("GET", "/users/1/")
+	if e.Status != 301 || e.Redirect != "/users/1" || !hasStep(e, StepRoutePath, true, "trailing slash") {
+		t.Fatalf("unexpected explanation %s", e)
+	}
+
+	e = r.Explain("GET", "/apx")
+	if e.Status != 404 || !hasStep(e, StepStatic, false, "prefix mismatch") {
+		t.Fatalf("unexpected explanation %s", e)
+	}
+
+	if e = r.Explain("BREW", "/"); e.Status != 405 {
+		t.Fatalf("unexpected explanation %s", e)
+	}
+}
+
+func TestMuxTraceHeader(t *testing.T) {
+	r := NewRouter(WithTraceHeader("X-Route-Trace"))
+	r.Route("/api", func(r Router) {
+		r.Get("/items/{id}", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("item"))
+		})
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	resp, body := testRequest(t, ts, "GET", "/api/items/1", nil)
+	if body != "item" {
+		t.Fatalf("unexpected body '%s'", body)
+	}
+	expected := "+static:/api +static:/ +catch-all:*(value 'items/1') +endpoint:/api/*(GET) +mount:/api/* +static:/items/ +param:{}(value '1') +endpoint:/items/{id}(GET)"
+	if trace := resp.Header.Get("X-Route-Trace"); trace != expected {
+		t.Fatalf("expecting trace '%s', got '%s'", expected, trace)
+	}
+
+	resp, _ = testRequest(t, ts, "GET", "/other", nil)
+	if resp.StatusCode != 404 || resp.Header.Get("X-Route-Trace") == "" {
+		t.Fatalf("expecting a traced 404, got %d '%s'", resp.StatusCode, resp.Header.Get("X-Route-Trace"))
+	}
+
+	plain := NewRouter()
+	plain.Get("/", func(w http.ResponseWriter, r *http.Request) {})
+	if resp, _ := testHandler(t, plain, "GET", "/", nil); resp.Header.Get("X-Route-Trace") != "" {
+		t.Fatal("unexpected trace header")
+	}
+}
+
+func hasStep(e *Explanation, kind StepKind, matched bool, reason string) bool {
+	for _, s := range e.Steps {
+		if s.Kind == kind && s.Matched == matched && s.Reason == reason {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,387 @@
+package gor
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FileServerOption configures a FileServer.
+type FileServerOption func(fsrv *fileServer)
+
+// SPAFallback serves the `index` file, e.g. "index.html", for the paths without a file extension matching no file,
+// so that a single page application can route them on the client side.
+func SPAFallback(index string) FileServerOption {
+	return func(fsrv *fileServer) {
+		fsrv.spaIndex = strings.TrimPrefix(index, "/")
+	}
+}
+
+// DirectoryListing lists the files of the directories without an index.html file, which are not found by default.
+func DirectoryListing(enabled bool) FileServerOption {
+	return func(fsrv *fileServer) {
+		fsrv.listing = enabled
+	}
+}
+
+// CacheControl sets the Cache-Control header of the files whose path matches the `glob` pattern, see path.Match,
+// to `value`, e.g. CacheControl("assets/*", "public, max-age=31536000, immutable").
+// Patterns without a slash match the base name of the files. The first matching pattern applies.
+func CacheControl(glob, value string) FileServerOption {
+	if _, err := path.Match(glob, ""); err != nil {
+		panic(fmt.Sprintf("gor: invalid cache control pattern '%s'", glob))
+	}
+
+	return func(fsrv *fileServer) {
+		fsrv.cacheControl = append(fsrv.cacheControl, cacheRule{glob: glob, value: value})
+	}
+}
+
+type cacheRule struct {
+	glob  string
+	value string
+}
+
+type fileServer struct {
+	fsys    fs.FS
+	pattern string
+
+	spaIndex     string
+	listing      bool
+	cacheControl []cacheRule
+
+	// strong ETags of the recently served files
+	etags etagCache
+}
+
+// maxETags is the number of files whose ETag a FileServer keeps, evicting the least recently served ones.
+const maxETags = 1024
+
+// etagCache is a cache of the ETags of the files, keyed by name, whose entries are valid
+// as long as the size and the modification time of the file are unchanged.
+type etagCache struct {
+	mu      sync.Mutex
+	max     int
+	entries map[string]*list.Element
+	lru     list.List // of *etagEntry, the most recently used first
+}
+
+type etagEntry struct {
+	name    string
+	size    int64
+	modTime time.Time
+	etag    string
+}
+
+// get returns the cached ETag of the file `name`, if it has not changed since.
+func (c *etagCache) get(name string, fi fs.FileInfo) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.entries[name]
+	if !ok {
+		return "", false
+	}
+	e := el.Value.(*etagEntry)
+	if e.size != fi.Size() || !e.modTime.Equal(fi.ModTime()) {
+		return "", false
+	}
+
+	c.lru.MoveToFront(el)
+	return e.etag, true
+}
+
+// add caches the ETag of the file `name`, replacing its previous one and evicting the least recently used entry
+// when the cache is full.
+func (c *etagCache) add(name string, fi fs.FileInfo, etag string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := &etagEntry{name: name, size: fi.Size(), modTime: fi.ModTime(), etag: etag}
+	if el, ok := c.entries[name]; ok {
+		el.Value = e
+		c.lru.MoveToFront(el)
+		return
+	}
+
+	if c.entries == nil {
+		c.entries = make(map[string]*list.Element)
+	}
+	c.entries[name] = c.lru.PushFront(e)
+
+	if c.lru.Len() > c.max {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*etagEntry).name)
+	}
+}
+
+// FileServer returns a handler serving the files of `fsys`, e.g. an embed.FS or an os.DirFS, e.g.
+//
+//	r.Mount("/static", gor.FileServer("/static", os.DirFS("public"), gor.CacheControl("*.js", "max-age=3600")))
+//
+// The file path is the routing path left by Mount when the handler is mounted directly,
+// or the last catch-all param of its route such as "/static/*" or "/static/{path...}", or else the request path without the `pattern` prefix when the handler is not routed by a gor router.
+// GET and HEAD requests are served with a strong ETag, and support conditional and range requests.
+// The files which are not an io.Seeker, e.g. the ones of a zip.Reader, are streamed without an ETag nor range support.
+// The precompressed "name.gz" sibling of a file, if any, is served instead of it when the request accepts gzip.
+// Directories are served their index.html file.
+func FileServer(pattern string, fsys fs.FS, opts ...FileServerOption) http.Handler {
+	if strings.ContainsAny(pattern, "{}") {
+		panic(fmt.Sprintf("gor: FileServer does not permit URL parameters in '%s'", pattern))
+	}
+
+	fsrv := &fileServer{fsys: fsys, pattern: strings.TrimSuffix(strings.TrimSuffix(pattern, "*"), "/")}
+	fsrv.etags.max = maxETags
+	for _, opt := range opts {
+		opt(fsrv)
+	}
+	return fsrv
+}
+
+func (fsrv *fileServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	urlPath := fsrv.filePath(r)
+	name := strings.TrimPrefix(path.Clean("/"+urlPath), "/")
+	if name == "" {
+		name = "."
+	}
+
+	fi, err := fs.Stat(fsrv.fsys, name)
+	if err == nil && fi.IsDir() {
+		// redirect to the canonical path of the directory so that the relative links resolve
+		if !strings.HasSuffix(r.URL.Path, "/") {
+			u := *r.URL
+			u.Path += "/"
+			http.Redirect(w, r, u.String(), http.StatusMovedPermanently)
+			return
+		}
+
+		index := path.Join(name, "index.html")
+		if ifi, err := fs.Stat(fsrv.fsys, index); err == nil && !ifi.IsDir() {
+			fsrv.serveFile(w, r, index)
+			return
+		}
+
+		if fsrv.listing {
+			fsrv.serveDir(w, r, name)
+			return
+		}
+		err = fs.ErrNotExist
+	}
+
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && fsrv.spaIndex != "" && path.Ext(name) == "" {
+			fsrv.serveFile(w, r, fsrv.spaIndex)
+			return
+		}
+		fsrv.serveError(w, r, err)
+		return
+	}
+
+	fsrv.serveFile(w, r, name)
+}
+
+// filePath returns the path of the requested file.
+func (fsrv *fileServer) filePath(r *http.Request) string {
+	rctx := RouteContext(r.Context())
+	if rctx == nil {
+		return strings.TrimPrefix(r.URL.Path, fsrv.pattern)
+	}
+
+	if rctx.mounted {
+		return rctx.RoutePath
+	}
+
+	// the last catch-all param of the route, e.g. "/static/*" or "/static/{path...}"
+	if key := catchAllKey(rctx.routePattern); key != "" {
+		for i := len(rctx.routeParams.Keys) - 1; i >= 0; i-- {
+			if rctx.routeParams.Keys[i] == key {
+				return rctx.routeParams.Values[i]
+			}
+		}
+	}
+	return strings.TrimPrefix(r.URL.Path, fsrv.pattern)
+}
+
+// catchAllKey returns the key of the last catch-all param of a routing pattern, "*" or the name of a "{name...}" wildcard,
+// or "" if the pattern has none.
+func catchAllKey(pattern string) (key string) {
+	for pattern != "" {
+		typ, k, _, _, _, pe := patNextSegment(pattern)
+		if typ == ntStatic {
+			break
+		}
+		if typ == ntCatchAll {
+			key = k
+		}
+		pattern = pattern[pe:]
+	}
+	return key
+}
+
+func (fsrv *fileServer) serveFile(w http.ResponseWriter, r *http.Request, name string) {
+	h := w.Header()
+
+	for _, rule := range fsrv.cacheControl {
+		target := name
+		if !strings.Contains(rule.glob, "/") {
+			target = path.Base(name)
+		}
+		if ok, _ := path.Match(rule.glob, target); ok {
+			h.Set("Cache-Control", rule.value)
+			break
+		}
+	}
+
+	ctype := mime.TypeByExtension(path.Ext(name))
+	if ctype != "" {
+		h.Set("Content-Type", ctype)
+	}
+
+	// serve the precompressed sibling of the file to the clients accepting gzip
+	served := name
+	if fi, err := fs.Stat(fsrv.fsys, name+".gz"); err == nil && !fi.IsDir() {
+		h.Add("Vary", "Accept-Encoding")
+		if acceptsGzip(r) {
+			served = name + ".gz"
+			h.Set("Content-Encoding", "gzip")
+		}
+	}
+
+	f, err := fsrv.fsys.Open(served)
+	if err != nil {
+		fsrv.serveError(w, r, err)
+		return
+	}
+	defer f.Close()
+
+	fi, err := f.Stat()
+	if err != nil {
+		fsrv.serveError(w, r, err)
+		return
+	}
+
+	// ServeContent sniffs the unknown content types, which it must not do on compressed content
+	if ctype == "" && served != name {
+		h.Set("Content-Type", "application/octet-stream")
+	}
+
+	content, ok := f.(io.ReadSeeker)
+	if !ok {
+		fsrv.streamFile(w, r, fi, f)
+		return
+	}
+
+	etag, err := fsrv.etag(served, fi, content)
+	if err != nil {
+		fsrv.serveError(w, r, err)
+		return
+	}
+	h.Set("Etag", etag)
+
+	http.ServeContent(w, r, name, fi.ModTime(), content)
+}
+
+// streamFile serves a file which cannot seek, without reading it in memory to support conditional and range requests.
+func (fsrv *fileServer) streamFile(w http.ResponseWriter, r *http.Request, fi fs.FileInfo, f io.Reader) {
+	h := w.Header()
+	if h.Get("Content-Type") == "" {
+		h.Set("Content-Type", "application/octet-stream")
+	}
+	if !fi.ModTime().IsZero() {
+		h.Set("Last-Modified", fi.ModTime().UTC().Format(http.TimeFormat))
+	}
+	h.Set("Content-Length", strconv.FormatInt(fi.Size(), 10))
+
+	w.WriteHeader(http.StatusOK)
+	if r.Method != http.MethodHead {
+		io.Copy(w, f)
+	}
+}
+
+// etag returns the strong ETag of the file, hashing its content unless it is cached.
+func (fsrv *fileServer) etag(name string, fi fs.FileInfo, content io.ReadSeeker) (string, error) {
+	if etag, ok := fsrv.etags.get(name, fi); ok {
+		return etag, nil
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, content); err != nil {
+		return "", err
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	etag := `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+	fsrv.etags.add(name, fi, etag)
+	return etag, nil
+}
+
+func (fsrv *fileServer) serveDir(w http.ResponseWriter, r *http.Request, name string) {
+	entries, err := fs.ReadDir(fsrv.fsys, name)
+	if err != nil {
+		fsrv.serveError(w, r, err)
+		return
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintln(w, "<pre>")
+	for _, e := range entries {
+		entry := e.Name()
+		if e.IsDir() {
+			entry += "/"
+		}
+		u := url.URL{Path: entry}
+		fmt.Fprintf(w, "<a href=\"%s\">%s</a>\n", u.String(), html.EscapeString(entry))
+	}
+	fmt.Fprintln(w, "</pre>")
+}
+
+func (fsrv *fileServer) serveError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, fs.ErrNotExist), errors.Is(err, fs.ErrInvalid):
+		http.NotFound(w, r)
+	case errors.Is(err, fs.ErrPermission):
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+	default:
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// acceptsGzip reports whether the Accept-Encoding header of the request accepts gzip.
+func acceptsGzip(r *http.Request) bool {
+	q, specificity := 0.0, -1
+	for _, spec := range ParseAccept(r.Header.Get("Accept-Encoding")) {
+		switch {
+		case spec.Value == "gzip" || spec.Value == "x-gzip":
+			q, specificity = spec.Q, 1
+		case spec.Value == "*" && specificity < 0:
+			q, specificity = spec.Q, 0
+		}
+	}
+	return q > 0
+}
@@ -0,0 +1,245 @@
+package gor
+
+import (
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestFileServer(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.html":        {Data: []byte("<h1>app</h1>")},
+		"app.js":            {Data: []byte("console.log(1)")},
+		"assets/app.css":    {Data: []byte("body{}")},
+		"assets/app.css.gz": {Data: []byte("gzipped")},
+		"docs/readme.txt":   {Data: []byte("readme")},
+	}
+
+	r := NewRouter()
+	r.Mount("/static", FileServer("/static", fsys,
+		SPAFallback("index.html"),
+		CacheControl("assets/*", "public, max-age=31536000, immutable"),
+		CacheControl("*.js", "no-cache"),
+	))
+	r.Mount("/browse", FileServer("/browse", fsys, DirectoryListing(true)))
+
+	serve := func(method, path string, header ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, nil)
+		for i := 0; i+1 < len(header); i += 2 {
+			req.Header.Set(header[i], header[i+1])
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	w := serve("GET", "/static/app.js")
+	if w.Code != 200 || w.Body.String() != "console.log(1)" || w.Header().Get("Cache-Control") != "no-cache" {
+		t.Fatalf("app.js: %d %q %v", w.Code, w.Body.String(), w.Header())
+	}
+	if !strings.HasPrefix(w.Header().Get("Content-Type"), "text/javascript") {
+		t.Fatalf("app.js: unexpected content type %q", w.Header().Get("Content-Type"))
+	}
+
+	etag := w.Header().Get("Etag")
+	if len(etag) != 34 || etag[0] != '"' {
+		t.Fatalf("app.js: expecting a strong etag, got %q", etag)
+	}
+	if w = serve("GET", "/static/app.js", "If-None-Match", etag); w.Code != 304 {
+		t.Fatalf("app.js: expecting 304, got %d", w.Code)
+	}
+
+	if w = serve("GET", "/static/app.js", "Range", "bytes=0-6"); w.Code != 206 || w.Body.String() != "console" {
+		t.Fatalf("app.js range: %d %q", w.Code, w.Body.String())
+	}
+
+	if w = serve("GET", "/static/"); w.Code != 200 || w.Body.String() != "<h1>app</h1>" {
+		t.Fatalf("index: %d %q", w.Code, w.Body.String())
+	}
+
+	if w = serve("GET", "/static/users/1"); w.Code != 200 || w.Body.String() != "<h1>app</h1>" {
+		t.Fatalf("spa: %d %q", w.Code, w.Body.String())
+	}
+
+	if w = serve("GET", "/static/missing.js"); w.Code != 404 {
+		t.Fatalf("missing.js: expecting 404, got %d", w.Code)
+	}
+
+	w = serve("GET", "/static/assets/app.css", "Accept-Encoding", "br, gzip")
+	if w.Body.String() != "gzipped" || w.Header().Get("Content-Encoding") != "gzip" || w.Header().Get("Vary") != "Accept-Encoding" ||
+		!strings.HasPrefix(w.Header().Get("Content-Type"), "text/css") || !strings.Contains(w.Header().Get("Cache-Control"), "immutable") {
+		t.Fatalf("app.css gzip: %q %v", w.Body.String(), w.Header())
+	}
+
+	w = serve("GET", "/static/assets/app.css", "Accept-Encoding", "*, gzip;q=0")
+	if w.Body.String() != "body{}" || w.Header().Get("Content-Encoding") != "" {
+		t.Fatalf("app.css: %q %v", w.Body.String(), w.Header())
+	}
+
+	if w = serve("GET", "/browse/docs"); w.Code != 301 || w.Header().Get("Location") != "/browse/docs/" {
+		t.Fatalf("docs: %d %v", w.Code, w.Header())
+	}
+
+	if w = serve("GET", "/browse/docs/"); w.Code != 200 || !strings.Contains(w.Body.String(), `<a href="readme.txt">readme.txt</a>`) {
+		t.Fatalf("docs listing: %d %q", w.Code, w.Body.String())
+	}
+
+	if w = serve("GET", "/static/docs/"); w.Code != 200 || w.Body.String() != "<h1>app</h1>" {
+		t.Fatalf("docs without listing: %d %q", w.Code, w.Body.String())
+	}
+
+	if w = serve("GET", "/browse/../index.html"); w.Code != 200 || w.Body.String() != "<h1>app</h1>" {
+		t.Fatalf("parent path: %d %q", w.Code, w.Body.String())
+	}
+
+	if w = serve("POST", "/static/app.js"); w.Code != 405 || w.Header().Get("Allow") != "GET, HEAD" {
+		t.Fatalf("post: %d %v", w.Code, w.Header())
+	}
+}
+
+func TestFileServerDirFS(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "hello.txt"), []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewRouter()
+	r.Route("/api", func(r Router) {
+		r.Get("/files/*", FileServer("/api/files", os.DirFS(dir)).ServeHTTP)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	if _, body := testRequest(t, ts, "GET", "/api/files/hello.txt", nil); body != "hello" {
+		t.Fatalf(body)
+	}
+	if resp, _ := testRequest(t, ts, "GET", "/api/files/nope.txt", nil); resp.StatusCode != 404 {
+		t.Fatalf("expecting 404, got %d", resp.StatusCode)
+	}
+
+	// without a gor router, the pattern is stripped from the request path
+	mux := http.NewServeMux()
+	mux.Handle("/files/", FileServer("/files", os.DirFS(dir)))
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest("GET", "/files/hello.txt", nil))
+	if w.Body.String() != "hello" {
+		t.Fatalf(w.Body.String())
+	}
+}
+
+func TestFileServerWildcard(t *testing.T) {
+	fsys := fstest.MapFS{
+		"index.html":        {Data: []byte("index")},
+		"app.js":            {Data: []byte("app")},
+		"static/index.html": {Data: []byte("static index")},
+	}
+
+	sub := NewRouter()
+	sub.Get("/static/*", FileServer("/assets/static", fsys).ServeHTTP)
+	sub.Get("/files/{path...}", FileServer("/assets/files", fsys).ServeHTTP)
+	sub.Mount("/public", FileServer("/assets/public", fsys))
+
+	r := NewRouter()
+	r.Mount("/assets", sub)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		path string
+		body string
+	}{
+		{"/assets/static/", "index"},
+		{"/assets/static/app.js", "app"},
+		{"/assets/static/static/", "static index"},
+		{"/assets/files/", "index"},
+		{"/assets/files/app.js", "app"},
+		{"/assets/public/", "index"},
+		{"/assets/public/app.js", "app"},
+	}
+	for _, tt := range tests {
+		if resp, body := testRequest(t, ts, "GET", tt.path, nil); resp.StatusCode != 200 || body != tt.body {
+			t.Fatalf("%s: %d %q, expecting %q", tt.path, resp.StatusCode, body, tt.body)
+		}
+	}
+}
+
+// streamFS hides the Seek method of the files of its fs.FS.
+type streamFS struct{ fs.FS }
+
+type streamFile struct{ fs.File }
+
+func (s streamFS) Open(name string) (fs.File, error) {
+	f, err := s.FS.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return streamFile{f}, nil
+}
+
+func TestFileServerStream(t *testing.T) {
+	fsys := streamFS{fstest.MapFS{"data.txt": {Data: []byte("streamed"), ModTime: time.Unix(1700000000, 0)}}}
+	h := FileServer("/", fsys)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/data.txt", nil)
+	req.Header.Set("Range", "bytes=0-1")
+	h.ServeHTTP(w, req)
+
+	if w.Code != 200 || w.Body.String() != "streamed" || w.Header().Get("Etag") != "" ||
+		w.Header().Get("Content-Length") != "8" || w.Header().Get("Last-Modified") != "Tue, 14 Nov 2023 22:13:20 GMT" {
+		t.Fatalf("unexpected response %d %q %v", w.Code, w.Body.String(), w.Header())
+	}
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("HEAD", "/data.txt", nil))
+	if w.Code != 200 || w.Body.Len() != 0 {
+		t.Fatalf("unexpected HEAD response %d %q", w.Code, w.Body.String())
+	}
+}
+
+func TestETagCache(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a": {Data: []byte("a")},
+		"b": {Data: []byte("b")},
+		"c": {Data: []byte("c")},
+	}
+	stat := func(name string) fs.FileInfo {
+		fi, err := fs.Stat(fsys, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fi
+	}
+
+	c := etagCache{max: 2}
+	c.add("a", stat("a"), `"a"`)
+	c.add("b", stat("b"), `"b"`)
+	if _, ok := c.get("a", stat("a")); !ok {
+		t.Fatal("expecting the etag of a")
+	}
+
+	// c evicts b, the least recently used entry
+	c.add("c", stat("c"), `"c"`)
+	if _, ok := c.get("b", stat("b")); ok || c.lru.Len() != 2 || len(c.entries) != 2 {
+		t.Fatalf("expecting b to be evicted, got %d entries", c.lru.Len())
+	}
+
+	// a changed file replaces its entry
+	fsys["a"] = &fstest.MapFile{Data: []byte("aa")}
+	if _, ok := c.get("a", stat("a")); ok {
+		t.Fatal("unexpected etag of the changed file")
+	}
+	c.add("a", stat("a"), `"aa"`)
+	if etag, ok := c.get("a", stat("a")); !ok || etag != `"aa"` || c.lru.Len() != 2 {
+		t.Fatalf("unexpected etag %q of the changed file", etag)
+	}
+}
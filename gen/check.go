@@ -0,0 +1,155 @@
+package gen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pchchv/gor"
+)
+
+// Check looks the paths up with each method known to the mux and an unknown method, both in the mux
+// with gor.Mux.Match and with the matcher generated from it, returning an error describing the first difference
+// of their results, if any: whether a route matches, the route, the URL params, the routing patterns and paths,
+// or else the allowed methods. Along with the paths, Check looks up sample paths derived from the routing patterns.
+func Check(mx *gor.Mux, match Matcher, routes []Route, paths ...string) error {
+	methods := append(mx.RouteTree().Methods, "BREW")
+
+	paths = append(samplePaths(mx), paths...)
+	for _, path := range paths {
+		for _, method := range methods {
+			if err := compare(mx, match, routes, method, path); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func compare(mx *gor.Mux, match Matcher, routes []Route, method, path string) error {
+	want := gor.NewRouteContext()
+	ok := mx.Match(want, method, path)
+
+	got := gor.NewRouteContext()
+	res := match(got, method, path)
+
+	diff := func(format string, args ...interface{}) error {
+		return fmt.Errorf("gen: %s %s: %s", method, path, fmt.Sprintf(format, args...))
+	}
+
+	if found := res.Route >= 0; found != ok {
+		return diff("matcher found a route: %t, mux found a route: %t", found, ok)
+	}
+
+	if ok {
+		if res.Route >= len(routes) {
+			return diff("route %d out of the route table", res.Route)
+		}
+		rt := routes[res.Route]
+		if rt.Method != method || rt.Pattern != fullPattern(want.RoutePatterns) {
+			return diff("matcher found route %s %s, mux found pattern %s", rt.Method, rt.Pattern, fullPattern(want.RoutePatterns))
+		}
+	} else if !equal(res.AllowedMethods, want.AllowedMethods()) {
+		return diff("matcher allowed methods %q, mux allowed methods %q", res.AllowedMethods, want.AllowedMethods())
+	}
+
+	switch {
+	case !equal(got.URLParams.Keys, want.URLParams.Keys):
+		return diff("matcher param keys %q, mux param keys %q", got.URLParams.Keys, want.URLParams.Keys)
+	case !equal(got.URLParams.Values, want.URLParams.Values):
+		return diff("matcher param values %q, mux param values %q", got.URLParams.Values, want.URLParams.Values)
+	case !equal(got.RoutePatterns, want.RoutePatterns):
+		return diff("matcher patterns %q, mux patterns %q", got.RoutePatterns, want.RoutePatterns)
+	case got.RoutePath != want.RoutePath:
+		return diff("matcher routing path %q, mux routing path %q", got.RoutePath, want.RoutePath)
+	}
+
+	return nil
+}
+
+// fullPattern returns the full routing pattern of the routing patterns of the routers of a route,
+// as reported by gor.WalkRoutes.
+func fullPattern(patterns []string) string {
+	var b strings.Builder
+	for i, p := range patterns {
+		if i < len(patterns)-1 {
+			p = strings.TrimSuffix(p, "/*")
+		}
+		b.WriteString(p)
+	}
+	return b.String()
+}
+
+// samplePaths derives paths from the routing patterns of the routes of the mux: the params and the wildcards
+// are replaced with sample values, and the paths are also truncated and suffixed with a slash.
+func samplePaths(mx *gor.Mux) []string {
+	values := []string{"1", "x", "a-b", "a.b", "00000000-0000-0000-0000-000000000000", "2024-02-29", ""}
+	wildcards := []string{"", "a", "a/b"}
+
+	var paths []string
+	seen := make(map[string]bool)
+	add := func(p string) {
+		if !seen[p] {
+			seen[p] = true
+			paths = append(paths, p)
+		}
+	}
+
+	gor.WalkRoutes(mx, func(rt gor.RouteInfo) error {
+		for i := range values {
+			p := samplePath(rt.Pattern, values[i], wildcards[i%len(wildcards)])
+			add(p)
+			add(p + "/")
+			if j := strings.LastIndexByte(p, '/'); j > 0 {
+				add(p[:j])
+			}
+		}
+		return nil
+	})
+
+	return paths
+}
+
+// samplePath replaces the params of a routing pattern with the value and its wildcards with the wildcard value.
+func samplePath(pattern, value, wildcard string) string {
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '*':
+			b.WriteString(wildcard)
+		case '{':
+			depth := 0
+			j := i
+			for ; j < len(pattern); j++ {
+				if pattern[j] == '{' {
+					depth++
+				} else if pattern[j] == '}' {
+					if depth--; depth == 0 {
+						break
+					}
+				}
+			}
+			if strings.HasSuffix(pattern[i:j], "...") {
+				b.WriteString(wildcard)
+			} else {
+				b.WriteString(value)
+			}
+			i = j
+		default:
+			b.WriteByte(pattern[i])
+		}
+	}
+	return b.String()
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,440 @@
+// Package gen generates the Go source of a matcher equivalent to the routing tree of a gor router,
+// looking the routes up with switch statements on the bytes of the path instead of walking the tree.
+// The generated matcher records the same URL params and routing patterns as gor.Mux.Match in the
+// routing context, and reports the methods allowed on the paths matching routes of other methods.
+//
+//	src, err := gen.Generate(r, gen.Options{Package: "routes"})
+//	...
+//	err = os.WriteFile("routes/match_gen.go", src, 0o644)
+//
+// Check compares a generated matcher with the router it was generated from.
+package gen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/pchchv/gor"
+)
+
+// Options configure the generated source.
+type Options struct {
+	// Package is the name of the package of the generated source.
+	Package string
+
+	// Func is the name of the generated matcher function, "Match" by default.
+	// The route table of the matcher is named after it, e.g. "MatchRoutes".
+	Func string
+}
+
+// Generate returns the formatted Go source of a matcher equivalent to the routing tree of the mux
+// and of the sub-routers mounted on it, along with its route table.
+// The routes must not change while the source is generated. The muxes matching the routes on rewritten paths,
+// with case-insensitive, clean path or trailing slash options, and the muxes with host routers are not supported.
+func Generate(mx *gor.Mux, opts Options) ([]byte, error) {
+	if opts.Package == "" {
+		return nil, fmt.Errorf("gen: missing package name")
+	}
+	if opts.Func == "" {
+		opts.Func = "Match"
+	}
+
+	g := &generator{
+		opts:   opts,
+		prefix: string(unicode.ToLower(rune(opts.Func[0]))) + opts.Func[1:],
+		ids:    make(map[*gor.RouteNode]string),
+	}
+	if _, err := g.router(mx, ""); err != nil {
+		return nil, err
+	}
+
+	return format.Source(g.source())
+}
+
+// generator emits the functions of the matcher of a router and of its sub-routers,
+// one for each router and for each tree node with children.
+type generator struct {
+	opts   Options
+	prefix string
+
+	// methods known to the routers
+	methods []string
+
+	// emitted functions, in order
+	funcs []string
+
+	// function names of the emitted nodes
+	ids map[*gor.RouteNode]string
+
+	// route table entries, as Go expressions
+	table []string
+
+	// compiled regexps of the regexp nodes, as variable declarations
+	rexps []string
+
+	routers    int
+	usesString bool
+}
+
+func (g *generator) source() []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "// Code generated by github.com/pchchv/gor/gen. DO NOT EDIT.\n\npackage %s\n\nimport (\n", g.opts.Package)
+	if len(g.rexps) > 0 {
+		b.WriteString("\"regexp\"\n")
+	}
+	if g.usesString {
+		b.WriteString("\"strings\"\n")
+	}
+	b.WriteString("\n\"github.com/pchchv/gor\"\n\"github.com/pchchv/gor/gen\"\n)\n\n")
+
+	fmt.Fprintf(&b, "// %sRoutes is the route table of %s, indexed by gen.Result.Route.\n", g.opts.Func, g.opts.Func)
+	fmt.Fprintf(&b, "var %sRoutes = []gen.Route{\n%s}\n\n", g.opts.Func, strings.Join(g.table, ""))
+
+	if len(g.rexps) > 0 {
+		fmt.Fprintf(&b, "var (\n%s)\n\n", strings.Join(g.rexps, ""))
+	}
+
+	fmt.Fprintf(&b, "// %s looks the route of the method and the routing path up like gor.Mux.Match on the router it was generated from,\n", g.opts.Func)
+	b.WriteString("// recording the URL params, the routing patterns and the routing paths of the sub-routers in the routing context.\n")
+	fmt.Fprintf(&b, "func %s(rctx *gor.Context, method, path string) gen.Result {\n", g.opts.Func)
+	fmt.Fprintf(&b, "switch method {\ncase %s:\ndefault:\nreturn gen.Result{Route: -1}\n}\n\n", quoteAll(g.methods))
+	fmt.Fprintf(&b, "s := gen.State{Method: method}\nreturn %sRouter0(rctx, &s, path)\n}\n", g.prefix)
+
+	for _, f := range g.funcs {
+		b.WriteString("\n")
+		b.WriteString(f)
+	}
+
+	return b.Bytes()
+}
+
+// router emits the functions of the matcher of a mux, whose routes are mounted on the pattern prefix,
+// returning the name of its router function.
+func (g *generator) router(mx *gor.Mux, prefix string) (string, error) {
+	t := mx.RouteTree()
+	if len(t.Hosts) > 0 {
+		return "", fmt.Errorf("gen: host routers are not supported, found %q", t.Hosts)
+	}
+	if t.CaseInsensitive || t.CleanPath || t.TrailingSlash != gor.TrailingSlashStrict {
+		return "", fmt.Errorf("gen: the path matching options of the router mounted on '%s' are not supported", prefix+"/*")
+	}
+	if g.methods == nil {
+		g.methods = t.Methods
+	}
+
+	name := fmt.Sprintf("%sRouter%d", g.prefix, g.routers)
+	g.routers++
+	slot := g.reserve()
+
+	r := &routerGen{g: g, prefix: prefix, mounts: make(map[string][]int)}
+	root, err := r.node(t.Root)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "func %s(rctx *gor.Context, s *gen.State, path string) gen.Result {\n", name)
+	fmt.Fprintf(&b, "s.Reset()\nroute := %s(s, path)\nif route < 0 {\nreturn s.Result(-1)\n}\n", root)
+	fmt.Fprintf(&b, "s.Record(rctx, &%sRoutes[route])\n", g.opts.Func)
+
+	if len(r.mounts) > 0 {
+		b.WriteString("\nswitch route {\n")
+		for _, sub := range r.subs {
+			fmt.Fprintf(&b, "case %s:\n", joinInts(r.mounts[sub]))
+			fmt.Fprintf(&b, "rctx.RoutePath = s.NextRoutePath()\nreturn %s(rctx, s, rctx.RoutePath)\n", sub)
+		}
+		b.WriteString("}\n")
+	}
+
+	b.WriteString("return s.Result(route)\n}\n")
+	g.funcs[slot] = b.String()
+
+	return name, nil
+}
+
+// reserve reserves the place of a function, so that the functions are emitted from the callers to the callees.
+func (g *generator) reserve() int {
+	g.funcs = append(g.funcs, "")
+	return len(g.funcs) - 1
+}
+
+// routerGen emits the node functions of a router.
+type routerGen struct {
+	g *generator
+
+	// pattern prefix of the routes of the router
+	prefix string
+
+	// route ids of the mounts of the router, by sub-router function
+	mounts map[string][]int
+	subs   []string
+
+	// route ids of the endpoints of the nodes
+	endpoints map[*gor.RouteNode][]endpointID
+}
+
+type endpointID struct {
+	method string
+	id     int
+}
+
+// node emits the function looking the routes of the children of a node up, mirroring the traversal of the tree:
+// the static children are tried first, then the regexp children, the param children and the catch-all child,
+// the param and regexp children backtracking to the shorter values and the catch-all child to the shorter paths.
+// It returns the name of the function.
+func (r *routerGen) node(n *gor.RouteNode) (string, error) {
+	g := r.g
+	if name, ok := g.ids[n]; ok {
+		return name, nil
+	}
+
+	name := fmt.Sprintf("%sNode%d", g.prefix, len(g.ids))
+	g.ids[n] = name
+	slot := g.reserve()
+
+	var groups [4][]*gor.RouteNode
+	for _, c := range n.Children {
+		groups[kindOrder(c.Kind)] = append(groups[kindOrder(c.Kind)], c)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "func %s(s *gen.State, search string) int {\n", name)
+
+	if static := groups[0]; len(static) > 0 {
+		b.WriteString("if search != \"\" {\nswitch search[0] {\n")
+		for _, c := range static {
+			fmt.Fprintf(&b, "case %s:\n", quoteByte(c.Prefix[0]))
+			if len(c.Prefix) > 1 {
+				g.usesString = true
+				fmt.Fprintf(&b, "if strings.HasPrefix(search, %q) {\n", c.Prefix)
+			}
+			fmt.Fprintf(&b, "xsearch := search[%d:]\n", len(c.Prefix))
+			if err := r.leaf(&b, c, `xsearch == ""`); err != nil {
+				return "", err
+			}
+			if err := r.descend(&b, c, "xsearch"); err != nil {
+				return "", err
+			}
+			if len(c.Prefix) > 1 {
+				b.WriteString("}\n")
+			}
+		}
+		b.WriteString("}\n}\n")
+	}
+
+	for _, params := range groups[1:3] {
+		if len(params) == 0 {
+			continue
+		}
+
+		b.WriteString("if search != \"\" {\n")
+		for _, c := range params {
+			if err := r.param(&b, c); err != nil {
+				return "", err
+			}
+		}
+
+		// the last node of the group is tried again on the whole path, with an empty value
+		if last := params[len(params)-1]; len(last.Children) > 0 {
+			b.WriteString("s.Values = append(s.Values, \"\")\n")
+			if err := r.descend(&b, last, "search"); err != nil {
+				return "", err
+			}
+			b.WriteString("s.Pop()\n")
+		}
+		b.WriteString("}\n")
+	}
+
+	if catchAll := groups[3]; len(catchAll) > 0 {
+		c := catchAll[0]
+		if len(c.Children) > 0 {
+			g.usesString = true
+			b.WriteString("for p := strings.LastIndexByte(search, '/'); p > 0; p = strings.LastIndexByte(search[:p], '/') {\n")
+			b.WriteString("n := len(s.Values)\ns.Values = append(s.Values, search[:p])\n")
+			if err := r.descend(&b, c, "search[p:]"); err != nil {
+				return "", err
+			}
+			b.WriteString("s.Values = s.Values[:n]\n}\n")
+		}
+
+		b.WriteString("s.Values = append(s.Values, search)\n")
+		if err := r.leaf(&b, c, ""); err != nil {
+			return "", err
+		}
+		if err := r.descend(&b, c, `""`); err != nil {
+			return "", err
+		}
+		b.WriteString("s.Pop()\n")
+	}
+
+	b.WriteString("return -1\n}\n")
+	g.funcs[slot] = b.String()
+
+	return name, nil
+}
+
+// param emits the lookup of a param or regexp child, matching the value up to its tail byte.
+func (r *routerGen) param(b *strings.Builder, c *gor.RouteNode) error {
+	g := r.g
+	tail := quoteByte(c.Tail)
+
+	switch {
+	case c.Kind == gor.StepParam:
+		g.usesString = true
+		fmt.Fprintf(b, "if p := gen.ParamEnd(search, %s, false); p >= 0 && strings.IndexByte(search[:p], '/') < 0 {\n", tail)
+	case c.Typed:
+		fmt.Fprintf(b, "if p := gen.ParamEnd(search, %s, true); p >= 0 && gor.MatchParam(%q, search[:p]) {\n", tail, c.Prefix)
+	default:
+		rex := fmt.Sprintf("%sRexp%d", g.prefix, len(g.rexps))
+		g.rexps = append(g.rexps, fmt.Sprintf("%s = regexp.MustCompile(%q)\n", rex, c.Prefix))
+		fmt.Fprintf(b, "if p := gen.ParamEnd(search, %s, true); p >= 0 && %s.MatchString(search[:p]) {\n", tail, rex)
+	}
+
+	b.WriteString("n := len(s.Values)\ns.Values = append(s.Values, search[:p])\nxsearch := search[p:]\n")
+	if err := r.leaf(b, c, `xsearch == ""`); err != nil {
+		return err
+	}
+	if err := r.descend(b, c, "xsearch"); err != nil {
+		return err
+	}
+	b.WriteString("s.Values = s.Values[:n]\n}\n")
+
+	return nil
+}
+
+// leaf emits the lookup of the endpoint of the request method on a node ending routes, when the condition holds,
+// flagging the method as not allowed when the node has no endpoint for it.
+func (r *routerGen) leaf(b *strings.Builder, c *gor.RouteNode, cond string) error {
+	if !c.Leaf {
+		return nil
+	}
+
+	eps, err := r.endpointIDs(c)
+	if err != nil {
+		return err
+	}
+
+	if cond != "" {
+		fmt.Fprintf(b, "if %s {\n", cond)
+	}
+
+	methods := make([]string, len(eps))
+	if len(eps) > 0 {
+		b.WriteString("switch s.Method {\n")
+		for i, ep := range eps {
+			fmt.Fprintf(b, "case %q:\nreturn %d\n", ep.method, ep.id)
+			methods[i] = ep.method
+		}
+		b.WriteString("}\n")
+	}
+	fmt.Fprintf(b, "s.Allow(%s)\n", quoteAll(methods))
+
+	if cond != "" {
+		b.WriteString("}\n")
+	}
+	return nil
+}
+
+// descend emits the lookup of the children of a node, if any, in the search path expression.
+func (r *routerGen) descend(b *strings.Builder, c *gor.RouteNode, search string) error {
+	if len(c.Children) == 0 {
+		return nil
+	}
+
+	fn, err := r.node(c)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(b, "if r := %s(s, %s); r >= 0 {\nreturn r\n}\n", fn, search)
+	return nil
+}
+
+// endpointIDs adds the endpoints of a node to the route table, emitting the router of the sub-router mounted on it, if any.
+func (r *routerGen) endpointIDs(c *gor.RouteNode) ([]endpointID, error) {
+	if eps, ok := r.endpoints[c]; ok {
+		return eps, nil
+	}
+	if r.endpoints == nil {
+		r.endpoints = make(map[*gor.RouteNode][]endpointID)
+	}
+
+	methods := make([]string, 0, len(c.Endpoints))
+	for m := range c.Endpoints {
+		methods = append(methods, m)
+	}
+	sort.Strings(methods)
+
+	g := r.g
+	eps := make([]endpointID, len(methods))
+	for i, m := range methods {
+		ep := c.Endpoints[m]
+		eps[i] = endpointID{method: m, id: len(g.table)}
+		g.table = append(g.table, fmt.Sprintf("{Method: %q, Pattern: %q, RoutePattern: %q, ParamKeys: %s, Mount: %t},\n",
+			m, r.prefix+ep.Pattern, ep.Pattern, quoteSlice(ep.ParamKeys), c.SubRoutes != nil))
+	}
+	r.endpoints[c] = eps
+
+	if c.SubRoutes != nil && len(eps) > 0 {
+		sub, ok := c.SubRoutes.(*gor.Mux)
+		if !ok {
+			return nil, fmt.Errorf("gen: unsupported sub-router %T mounted on '%s'", c.SubRoutes, r.prefix+c.Endpoints[methods[0]].Pattern)
+		}
+
+		fn, err := g.router(sub, r.prefix+strings.TrimSuffix(c.Endpoints[methods[0]].Pattern, "/*"))
+		if err != nil {
+			return nil, err
+		}
+		for _, ep := range eps {
+			r.mounts[fn] = append(r.mounts[fn], ep.id)
+		}
+		r.subs = append(r.subs, fn)
+	}
+
+	return eps, nil
+}
+
+// kindOrder returns the index of the group of the nodes of a kind, in the order of their lookup.
+func kindOrder(kind gor.StepKind) int {
+	switch kind {
+	case gor.StepStatic:
+		return 0
+	case gor.StepRegexp:
+		return 1
+	case gor.StepParam:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func quoteByte(c byte) string {
+	return fmt.Sprintf("%q", rune(c))
+}
+
+func quoteAll(ss []string) string {
+	q := make([]string, len(ss))
+	for i, s := range ss {
+		q[i] = fmt.Sprintf("%q", s)
+	}
+	return strings.Join(q, ", ")
+}
+
+func quoteSlice(ss []string) string {
+	if ss == nil {
+		return "nil"
+	}
+	return "[]string{" + quoteAll(ss) + "}"
+}
+
+func joinInts(ids []int) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = fmt.Sprint(id)
+	}
+	return strings.Join(s, ", ")
+}
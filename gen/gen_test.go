@@ -0,0 +1,96 @@
+package gen_test
+
+import (
+	"flag"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/pchchv/gor"
+	"github.com/pchchv/gor/gen"
+	"github.com/pchchv/gor/gen/internal/testroutes"
+)
+
+var update = flag.Bool("update", false, "update the generated matcher of the testroutes package")
+
+const generated = "internal/testroutes/match_gen.go"
+
+func TestGenerate(t *testing.T) {
+	src, err := gen.Generate(testroutes.Router(), gen.Options{Package: "testroutes"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if *update {
+		if err := os.WriteFile(generated, src, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want, err := os.ReadFile(generated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(src) != string(want) {
+		t.Fatalf("%s is out of date, run go generate ./gen/...", generated)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	paths := []string{
+		"", "/", "//", "/ping/", "/users/", "/users//avatar.png", "/users/42/posts/", "/users/42/posts/a-b/c",
+		"/users/-7", "/users/99999999999999999999", "/users/bob/avatar.", "/users/bob/avatar.png/x",
+		"/files", "/files/", "/files/a/b/raw", "/files/a/raw/raw", "/files/raw", "/reports/2024", "/reports/2024/",
+		"/reports/x/1", "/archive/2024-02-30-1", "/archive/2023-12-31-", "/sessions/not-a-uuid",
+		"/api", "/api/", "/api/v1", "/api/v1/", "/api/v1/items/BEEF", "/api/v1/items/beef", "/static", "/static/a/b",
+	}
+
+	if err := gen.Check(testroutes.Router(), testroutes.Match, testroutes.MatchRoutes, paths...); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckMismatch(t *testing.T) {
+	r := testroutes.Router()
+	r.Get("/pings", func(w http.ResponseWriter, r *http.Request) {})
+
+	err := gen.Check(r, testroutes.Match, testroutes.MatchRoutes)
+	if err == nil || !strings.Contains(err.Error(), "/pings") {
+		t.Fatalf("expected a mismatch on /pings, got %v", err)
+	}
+}
+
+func TestGenerateUnsupported(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	folded := gor.NewMux(gor.WithCaseInsensitive())
+	folded.Get("/", h)
+
+	hosts := gor.NewRouter()
+	hosts.Host("{tenant}.example.com", func(r gor.Router) {
+		r.Get("/", h)
+	})
+
+	mounted := gor.NewRouter()
+	mounted.Mount("/v", gor.NewVersioned())
+
+	tests := []struct {
+		name string
+		mx   *gor.Mux
+		err  string
+	}{
+		{"options", folded, "path matching options"},
+		{"hosts", hosts, "host routers"},
+		{"sub-router", mounted, "unsupported sub-router"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := gen.Generate(tt.mx, gen.Options{Package: "routes"})
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected an error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
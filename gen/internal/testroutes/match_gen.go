@@ -0,0 +1,1108 @@
+// Code generated by github.com/pchchv/gor/gen. DO NOT EDIT.
+
+package testroutes
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pchchv/gor"
+	"github.com/pchchv/gor/gen"
+)
+
+// MatchRoutes is the route table of Match, indexed by gen.Result.Route.
+var MatchRoutes = []gen.Route{
+	{Method: "GET", Pattern: "/", RoutePattern: "/", ParamKeys: []string{}, Mount: false},
+	{Method: "CONNECT", Pattern: "/api", RoutePattern: "/api", ParamKeys: []string{}, Mount: false},
+	{Method: "DELETE", Pattern: "/api", RoutePattern: "/api", ParamKeys: []string{}, Mount: false},
+	{Method: "GET", Pattern: "/api", RoutePattern: "/api", ParamKeys: []string{}, Mount: false},
+	{Method: "HEAD", Pattern: "/api", RoutePattern: "/api", ParamKeys: []string{}, Mount: false},
+	{Method: "OPTIONS", Pattern: "/api", RoutePattern: "/api", ParamKeys: []string{}, Mount: false},
+	{Method: "PATCH", Pattern: "/api", RoutePattern: "/api", ParamKeys: []string{}, Mount: false},
+	{Method: "POST", Pattern: "/api", RoutePattern: "/api", ParamKeys: []string{}, Mount: false},
+	{Method: "PROPFIND", Pattern: "/api", RoutePattern: "/api", ParamKeys: []string{}, Mount: false},
+	{Method: "PUT", Pattern: "/api", RoutePattern: "/api", ParamKeys: []string{}, Mount: false},
+	{Method: "TRACE", Pattern: "/api", RoutePattern: "/api", ParamKeys: []string{}, Mount: false},
+	{Method: "CONNECT", Pattern: "/api/", RoutePattern: "/api/", ParamKeys: []string{}, Mount: false},
+	{Method: "DELETE", Pattern: "/api/", RoutePattern: "/api/", ParamKeys: []string{}, Mount: false},
+	{Method: "GET", Pattern: "/api/", RoutePattern: "/api/", ParamKeys: []string{}, Mount: false},
+	{Method: "HEAD", Pattern: "/api/", RoutePattern: "/api/", ParamKeys: []string{}, Mount: false},
+	{Method: "OPTIONS", Pattern: "/api/", RoutePattern: "/api/", ParamKeys: []string{}, Mount: false},
+	{Method: "PATCH", Pattern: "/api/", RoutePattern: "/api/", ParamKeys: []string{}, Mount: false},
+	{Method: "POST", Pattern: "/api/", RoutePattern: "/api/", ParamKeys: []string{}, Mount: false},
+	{Method: "PROPFIND", Pattern: "/api/", RoutePattern: "/api/", ParamKeys: []string{}, Mount: false},
+	{Method: "PUT", Pattern: "/api/", RoutePattern: "/api/", ParamKeys: []string{}, Mount: false},
+	{Method: "TRACE", Pattern: "/api/", RoutePattern: "/api/", ParamKeys: []string{}, Mount: false},
+	{Method: "CONNECT", Pattern: "/api/*", RoutePattern: "/api/*", ParamKeys: []string{"*"}, Mount: true},
+	{Method: "DELETE", Pattern: "/api/*", RoutePattern: "/api/*", ParamKeys: []string{"*"}, Mount: true},
+	{Method: "GET", Pattern: "/api/*", RoutePattern: "/api/*", ParamKeys: []string{"*"}, Mount: true},
+	{Method: "HEAD", Pattern: "/api/*", RoutePattern: "/api/*", ParamKeys: []string{"*"}, Mount: true},
+	{Method: "OPTIONS", Pattern: "/api/*", RoutePattern: "/api/*", ParamKeys: []string{"*"}, Mount: true},
+	{Method: "PATCH", Pattern: "/api/*", RoutePattern: "/api/*", ParamKeys: []string{"*"}, Mount: true},
+	{Method: "POST", Pattern: "/api/*", RoutePattern: "/api/*", ParamKeys: []string{"*"}, Mount: true},
+	{Method: "PROPFIND", Pattern: "/api/*", RoutePattern: "/api/*", ParamKeys: []string{"*"}, Mount: true},
+	{Method: "PUT", Pattern: "/api/*", RoutePattern: "/api/*", ParamKeys: []string{"*"}, Mount: true},
+	{Method: "TRACE", Pattern: "/api/*", RoutePattern: "/api/*", ParamKeys: []string{"*"}, Mount: true},
+	{Method: "GET", Pattern: "/api/", RoutePattern: "/", ParamKeys: []string{}, Mount: false},
+	{Method: "GET", Pattern: "/api/items/{id}", RoutePattern: "/items/{id}", ParamKeys: []string{"id"}, Mount: false},
+	{Method: "CONNECT", Pattern: "/api/v1", RoutePattern: "/v1", ParamKeys: []string{}, Mount: false},
+	{Method: "DELETE", Pattern: "/api/v1", RoutePattern: "/v1", ParamKeys: []string{}, Mount: false},
+	{Method: "GET", Pattern: "/api/v1", RoutePattern: "/v1", ParamKeys: []string{}, Mount: false},
+	{Method: "HEAD", Pattern: "/api/v1", RoutePattern: "/v1", ParamKeys: []string{}, Mount: false},
+	{Method: "OPTIONS", Pattern: "/api/v1", RoutePattern: "/v1", ParamKeys: []string{}, Mount: false},
+	{Method: "PATCH", Pattern: "/api/v1", RoutePattern: "/v1", ParamKeys: []string{}, Mount: false},
+	{Method: "POST", Pattern: "/api/v1", RoutePattern: "/v1", ParamKeys: []string{}, Mount: false},
+	{Method: "PROPFIND", Pattern: "/api/v1", RoutePattern: "/v1", ParamKeys: []string{}, Mount: false},
+	{Method: "PUT", Pattern: "/api/v1", RoutePattern: "/v1", ParamKeys: []string{}, Mount: false},
+	{Method: "TRACE", Pattern: "/api/v1", RoutePattern: "/v1", ParamKeys: []string{}, Mount: false},
+	{Method: "CONNECT", Pattern: "/api/v1/", RoutePattern: "/v1/", ParamKeys: []string{}, Mount: false},
+	{Method: "DELETE", Pattern: "/api/v1/", RoutePattern: "/v1/", ParamKeys: []string{}, Mount: false},
+	{Method: "GET", Pattern: "/api/v1/", RoutePattern: "/v1/", ParamKeys: []string{}, Mount: false},
+	{Method: "HEAD", Pattern: "/api/v1/", RoutePattern: "/v1/", ParamKeys: []string{}, Mount: false},
+	{Method: "OPTIONS", Pattern: "/api/v1/", RoutePattern: "/v1/", ParamKeys: []string{}, Mount: false},
+	{Method: "PATCH", Pattern: "/api/v1/", RoutePattern: "/v1/", ParamKeys: []string{}, Mount: false},
+	{Method: "POST", Pattern: "/api/v1/", RoutePattern: "/v1/", ParamKeys: []string{}, Mount: false},
+	{Method: "PROPFIND", Pattern: "/api/v1/", RoutePattern: "/v1/", ParamKeys: []string{}, Mount: false},
+	{Method: "PUT", Pattern: "/api/v1/", RoutePattern: "/v1/", ParamKeys: []string{}, Mount: false},
+	{Method: "TRACE", Pattern: "/api/v1/", RoutePattern: "/v1/", ParamKeys: []string{}, Mount: false},
+	{Method: "CONNECT", Pattern: "/api/v1/*", RoutePattern: "/v1/*", ParamKeys: []string{"*"}, Mount: true},
+	{Method: "DELETE", Pattern: "/api/v1/*", RoutePattern: "/v1/*", ParamKeys: []string{"*"}, Mount: true},
+	{Method: "GET", Pattern: "/api/v1/*", RoutePattern: "/v1/*", ParamKeys: []string{"*"}, Mount: true},
+	{Method: "HEAD", Pattern: "/api/v1/*", RoutePattern: "/v1/*", ParamKeys: []string{"*"}, Mount: true},
+	{Method: "OPTIONS", Pattern: "/api/v1/*", RoutePattern: "/v1/*", ParamKeys: []string{"*"}, Mount: true},
+	{Method: "PATCH", Pattern: "/api/v1/*", RoutePattern: "/v1/*", ParamKeys: []string{"*"}, Mount: true},
+	{Method: "POST", Pattern: "/api/v1/*", RoutePattern: "/v1/*", ParamKeys: []string{"*"}, Mount: true},
+	{Method: "PROPFIND", Pattern: "/api/v1/*", RoutePattern: "/v1/*", ParamKeys: []string{"*"}, Mount: true},
+	{Method: "PUT", Pattern: "/api/v1/*", RoutePattern: "/v1/*", ParamKeys: []string{"*"}, Mount: true},
+	{Method: "TRACE", Pattern: "/api/v1/*", RoutePattern: "/v1/*", ParamKeys: []string{"*"}, Mount: true},
+	{Method: "PATCH", Pattern: "/api/v1/items/{id:[0-9a-f]+}", RoutePattern: "/items/{id:[0-9a-f]+}", ParamKeys: []string{"id"}, Mount: false},
+	{Method: "GET", Pattern: "/api/v1/status", RoutePattern: "/status", ParamKeys: []string{}, Mount: false},
+	{Method: "GET", Pattern: "/archive/{date:date}-{n}", RoutePattern: "/archive/{date:date}-{n}", ParamKeys: []string{"date", "n"}, Mount: false},
+	{Method: "PROPFIND", Pattern: "/dav/{path...}", RoutePattern: "/dav/{path...}", ParamKeys: []string{"path"}, Mount: false},
+	{Method: "GET", Pattern: "/files/*/raw", RoutePattern: "/files/*/raw", ParamKeys: []string{"*"}, Mount: false},
+	{Method: "GET", Pattern: "/files/{path...}", RoutePattern: "/files/{path...}", ParamKeys: []string{"path"}, Mount: false},
+	{Method: "CONNECT", Pattern: "/health", RoutePattern: "/health", ParamKeys: []string{}, Mount: false},
+	{Method: "DELETE", Pattern: "/health", RoutePattern: "/health", ParamKeys: []string{}, Mount: false},
+	{Method: "GET", Pattern: "/health", RoutePattern: "/health", ParamKeys: []string{}, Mount: false},
+	{Method: "HEAD", Pattern: "/health", RoutePattern: "/health", ParamKeys: []string{}, Mount: false},
+	{Method: "OPTIONS", Pattern: "/health", RoutePattern: "/health", ParamKeys: []string{}, Mount: false},
+	{Method: "PATCH", Pattern: "/health", RoutePattern: "/health", ParamKeys: []string{}, Mount: false},
+	{Method: "POST", Pattern: "/health", RoutePattern: "/health", ParamKeys: []string{}, Mount: false},
+	{Method: "PROPFIND", Pattern: "/health", RoutePattern: "/health", ParamKeys: []string{}, Mount: false},
+	{Method: "PUT", Pattern: "/health", RoutePattern: "/health", ParamKeys: []string{}, Mount: false},
+	{Method: "TRACE", Pattern: "/health", RoutePattern: "/health", ParamKeys: []string{}, Mount: false},
+	{Method: "GET", Pattern: "/ping", RoutePattern: "/ping", ParamKeys: []string{}, Mount: false},
+	{Method: "POST", Pattern: "/ping", RoutePattern: "/ping", ParamKeys: []string{}, Mount: false},
+	{Method: "GET", Pattern: "/pong", RoutePattern: "/pong", ParamKeys: []string{}, Mount: false},
+	{Method: "GET", Pattern: "/reports/{year:uint}/{month?}", RoutePattern: "/reports/{year:uint}/{month?}", ParamKeys: []string{"year"}, Mount: false},
+	{Method: "GET", Pattern: "/reports/{year:uint}/{month?}", RoutePattern: "/reports/{year:uint}/{month?}", ParamKeys: []string{"year", "month"}, Mount: false},
+	{Method: "DELETE", Pattern: "/sessions/{id:uuid}", RoutePattern: "/sessions/{id:uuid}", ParamKeys: []string{"id"}, Mount: false},
+	{Method: "CONNECT", Pattern: "/static", RoutePattern: "/static", ParamKeys: []string{}, Mount: false},
+	{Method: "DELETE", Pattern: "/static", RoutePattern: "/static", ParamKeys: []string{}, Mount: false},
+	{Method: "GET", Pattern: "/static", RoutePattern: "/static", ParamKeys: []string{}, Mount: false},
+	{Method: "HEAD", Pattern: "/static", RoutePattern: "/static", ParamKeys: []string{}, Mount: false},
+	{Method: "OPTIONS", Pattern: "/static", RoutePattern: "/static", ParamKeys: []string{}, Mount: false},
+	{Method: "PATCH", Pattern: "/static", RoutePattern: "/static", ParamKeys: []string{}, Mount: false},
+	{Method: "POST", Pattern: "/static", RoutePattern: "/static", ParamKeys: []string{}, Mount: false},
+	{Method: "PROPFIND", Pattern: "/static", RoutePattern: "/static", ParamKeys: []string{}, Mount: false},
+	{Method: "PUT", Pattern: "/static", RoutePattern: "/static", ParamKeys: []string{}, Mount: false},
+	{Method: "TRACE", Pattern: "/static", RoutePattern: "/static", ParamKeys: []string{}, Mount: false},
+	{Method: "CONNECT", Pattern: "/static/", RoutePattern: "/static/", ParamKeys: []string{}, Mount: false},
+	{Method: "DELETE", Pattern: "/static/", RoutePattern: "/static/", ParamKeys: []string{}, Mount: false},
+	{Method: "GET", Pattern: "/static/", RoutePattern: "/static/", ParamKeys: []string{}, Mount: false},
+	{Method: "HEAD", Pattern: "/static/", RoutePattern: "/static/", ParamKeys: []string{}, Mount: false},
+	{Method: "OPTIONS", Pattern: "/static/", RoutePattern: "/static/", ParamKeys: []string{}, Mount: false},
+	{Method: "PATCH", Pattern: "/static/", RoutePattern: "/static/", ParamKeys: []string{}, Mount: false},
+	{Method: "POST", Pattern: "/static/", RoutePattern: "/static/", ParamKeys: []string{}, Mount: false},
+	{Method: "PROPFIND", Pattern: "/static/", RoutePattern: "/static/", ParamKeys: []string{}, Mount: false},
+	{Method: "PUT", Pattern: "/static/", RoutePattern: "/static/", ParamKeys: []string{}, Mount: false},
+	{Method: "TRACE", Pattern: "/static/", RoutePattern: "/static/", ParamKeys: []string{}, Mount: false},
+	{Method: "CONNECT", Pattern: "/static/*", RoutePattern: "/static/*", ParamKeys: []string{"*"}, Mount: false},
+	{Method: "DELETE", Pattern: "/static/*", RoutePattern: "/static/*", ParamKeys: []string{"*"}, Mount: false},
+	{Method: "GET", Pattern: "/static/*", RoutePattern: "/static/*", ParamKeys: []string{"*"}, Mount: false},
+	{Method: "HEAD", Pattern: "/static/*", RoutePattern: "/static/*", ParamKeys: []string{"*"}, Mount: false},
+	{Method: "OPTIONS", Pattern: "/static/*", RoutePattern: "/static/*", ParamKeys: []string{"*"}, Mount: false},
+	{Method: "PATCH", Pattern: "/static/*", RoutePattern: "/static/*", ParamKeys: []string{"*"}, Mount: false},
+	{Method: "POST", Pattern: "/static/*", RoutePattern: "/static/*", ParamKeys: []string{"*"}, Mount: false},
+	{Method: "PROPFIND", Pattern: "/static/*", RoutePattern: "/static/*", ParamKeys: []string{"*"}, Mount: false},
+	{Method: "PUT", Pattern: "/static/*", RoutePattern: "/static/*", ParamKeys: []string{"*"}, Mount: false},
+	{Method: "TRACE", Pattern: "/static/*", RoutePattern: "/static/*", ParamKeys: []string{"*"}, Mount: false},
+	{Method: "GET", Pattern: "/users", RoutePattern: "/users", ParamKeys: []string{}, Mount: false},
+	{Method: "POST", Pattern: "/users", RoutePattern: "/users", ParamKeys: []string{}, Mount: false},
+	{Method: "GET", Pattern: "/users/me", RoutePattern: "/users/me", ParamKeys: []string{}, Mount: false},
+	{Method: "GET", Pattern: "/users/{id:int}", RoutePattern: "/users/{id:int}", ParamKeys: []string{"id"}, Mount: false},
+	{Method: "PUT", Pattern: "/users/{id:int}", RoutePattern: "/users/{id:int}", ParamKeys: []string{"id"}, Mount: false},
+	{Method: "GET", Pattern: "/users/{id:int}/posts/{slug:[a-z-]+}", RoutePattern: "/users/{id:int}/posts/{slug:[a-z-]+}", ParamKeys: []string{"id", "slug"}, Mount: false},
+	{Method: "GET", Pattern: "/users/{name}", RoutePattern: "/users/{name}", ParamKeys: []string{"name"}, Mount: false},
+	{Method: "GET", Pattern: "/users/{name}/avatar.{ext}", RoutePattern: "/users/{name}/avatar.{ext}", ParamKeys: []string{"name", "ext"}, Mount: false},
+}
+
+var (
+	matchRexp0 = regexp.MustCompile("^[0-9a-f]+$")
+	matchRexp1 = regexp.MustCompile("^[a-z-]+$")
+)
+
+// Match looks the route of the method and the routing path up like gor.Mux.Match on the router it was generated from,
+// recording the URL params, the routing patterns and the routing paths of the sub-routers in the routing context.
+func Match(rctx *gor.Context, method, path string) gen.Result {
+	switch method {
+	case "CONNECT", "DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PROPFIND", "PUT", "TRACE":
+	default:
+		return gen.Result{Route: -1}
+	}
+
+	s := gen.State{Method: method}
+	return matchRouter0(rctx, &s, path)
+}
+
+func matchRouter0(rctx *gor.Context, s *gen.State, path string) gen.Result {
+	s.Reset()
+	route := matchNode0(s, path)
+	if route < 0 {
+		return s.Result(-1)
+	}
+	s.Record(rctx, &MatchRoutes[route])
+
+	switch route {
+	case 21, 22, 23, 24, 25, 26, 27, 28, 29, 30:
+		rctx.RoutePath = s.NextRoutePath()
+		return matchRouter1(rctx, s, rctx.RoutePath)
+	}
+	return s.Result(route)
+}
+
+func matchNode0(s *gen.State, search string) int {
+	if search != "" {
+		switch search[0] {
+		case '/':
+			xsearch := search[1:]
+			if xsearch == "" {
+				switch s.Method {
+				case "GET":
+					return 0
+				}
+				s.Allow("GET")
+			}
+			if r := matchNode1(s, xsearch); r >= 0 {
+				return r
+			}
+		}
+	}
+	return -1
+}
+
+func matchNode1(s *gen.State, search string) int {
+	if search != "" {
+		switch search[0] {
+		case 'a':
+			xsearch := search[1:]
+			if r := matchNode2(s, xsearch); r >= 0 {
+				return r
+			}
+		case 'd':
+			if strings.HasPrefix(search, "dav/") {
+				xsearch := search[4:]
+				if r := matchNode16(s, xsearch); r >= 0 {
+					return r
+				}
+			}
+		case 'f':
+			if strings.HasPrefix(search, "files/") {
+				xsearch := search[6:]
+				if r := matchNode17(s, xsearch); r >= 0 {
+					return r
+				}
+			}
+		case 'h':
+			if strings.HasPrefix(search, "health") {
+				xsearch := search[6:]
+				if xsearch == "" {
+					switch s.Method {
+					case "CONNECT":
+						return 69
+					case "DELETE":
+						return 70
+					case "GET":
+						return 71
+					case "HEAD":
+						return 72
+					case "OPTIONS":
+						return 73
+					case "PATCH":
+						return 74
+					case "POST":
+						return 75
+					case "PROPFIND":
+						return 76
+					case "PUT":
+						return 77
+					case "TRACE":
+						return 78
+					}
+					s.Allow("CONNECT", "DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PROPFIND", "PUT", "TRACE")
+				}
+			}
+		case 'p':
+			xsearch := search[1:]
+			if r := matchNode19(s, xsearch); r >= 0 {
+				return r
+			}
+		case 'r':
+			if strings.HasPrefix(search, "reports/") {
+				xsearch := search[8:]
+				if r := matchNode20(s, xsearch); r >= 0 {
+					return r
+				}
+			}
+		case 's':
+			xsearch := search[1:]
+			if r := matchNode23(s, xsearch); r >= 0 {
+				return r
+			}
+		case 'u':
+			if strings.HasPrefix(search, "users") {
+				xsearch := search[5:]
+				if xsearch == "" {
+					switch s.Method {
+					case "GET":
+						return 115
+					case "POST":
+						return 116
+					}
+					s.Allow("GET", "POST")
+				}
+				if r := matchNode27(s, xsearch); r >= 0 {
+					return r
+				}
+			}
+		}
+	}
+	return -1
+}
+
+func matchNode2(s *gen.State, search string) int {
+	if search != "" {
+		switch search[0] {
+		case 'p':
+			if strings.HasPrefix(search, "pi") {
+				xsearch := search[2:]
+				if xsearch == "" {
+					switch s.Method {
+					case "CONNECT":
+						return 1
+					case "DELETE":
+						return 2
+					case "GET":
+						return 3
+					case "HEAD":
+						return 4
+					case "OPTIONS":
+						return 5
+					case "PATCH":
+						return 6
+					case "POST":
+						return 7
+					case "PROPFIND":
+						return 8
+					case "PUT":
+						return 9
+					case "TRACE":
+						return 10
+					}
+					s.Allow("CONNECT", "DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PROPFIND", "PUT", "TRACE")
+				}
+				if r := matchNode3(s, xsearch); r >= 0 {
+					return r
+				}
+			}
+		case 'r':
+			if strings.HasPrefix(search, "rchive/") {
+				xsearch := search[7:]
+				if r := matchNode13(s, xsearch); r >= 0 {
+					return r
+				}
+			}
+		}
+	}
+	return -1
+}
+
+func matchNode3(s *gen.State, search string) int {
+	if search != "" {
+		switch search[0] {
+		case '/':
+			xsearch := search[1:]
+			if xsearch == "" {
+				switch s.Method {
+				case "CONNECT":
+					return 11
+				case "DELETE":
+					return 12
+				case "GET":
+					return 13
+				case "HEAD":
+					return 14
+				case "OPTIONS":
+					return 15
+				case "PATCH":
+					return 16
+				case "POST":
+					return 17
+				case "PROPFIND":
+					return 18
+				case "PUT":
+					return 19
+				case "TRACE":
+					return 20
+				}
+				s.Allow("CONNECT", "DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PROPFIND", "PUT", "TRACE")
+			}
+			if r := matchNode4(s, xsearch); r >= 0 {
+				return r
+			}
+		}
+	}
+	return -1
+}
+
+func matchNode4(s *gen.State, search string) int {
+	s.Values = append(s.Values, search)
+	switch s.Method {
+	case "CONNECT":
+		return 21
+	case "DELETE":
+		return 22
+	case "GET":
+		return 23
+	case "HEAD":
+		return 24
+	case "OPTIONS":
+		return 25
+	case "PATCH":
+		return 26
+	case "POST":
+		return 27
+	case "PROPFIND":
+		return 28
+	case "PUT":
+		return 29
+	case "TRACE":
+		return 30
+	}
+	s.Allow("CONNECT", "DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PROPFIND", "PUT", "TRACE")
+	s.Pop()
+	return -1
+}
+
+func matchRouter1(rctx *gor.Context, s *gen.State, path string) gen.Result {
+	s.Reset()
+	route := matchNode5(s, path)
+	if route < 0 {
+		return s.Result(-1)
+	}
+	s.Record(rctx, &MatchRoutes[route])
+
+	switch route {
+	case 53, 54, 55, 56, 57, 58, 59, 60, 61, 62:
+		rctx.RoutePath = s.NextRoutePath()
+		return matchRouter2(rctx, s, rctx.RoutePath)
+	}
+	return s.Result(route)
+}
+
+func matchNode5(s *gen.State, search string) int {
+	if search != "" {
+		switch search[0] {
+		case '/':
+			xsearch := search[1:]
+			if xsearch == "" {
+				switch s.Method {
+				case "GET":
+					return 31
+				}
+				s.Allow("GET")
+			}
+			if r := matchNode6(s, xsearch); r >= 0 {
+				return r
+			}
+		}
+	}
+	return -1
+}
+
+func matchNode6(s *gen.State, search string) int {
+	if search != "" {
+		switch search[0] {
+		case 'i':
+			if strings.HasPrefix(search, "items/") {
+				xsearch := search[6:]
+				if r := matchNode7(s, xsearch); r >= 0 {
+					return r
+				}
+			}
+		case 'v':
+			if strings.HasPrefix(search, "v1") {
+				xsearch := search[2:]
+				if xsearch == "" {
+					switch s.Method {
+					case "CONNECT":
+						return 33
+					case "DELETE":
+						return 34
+					case "GET":
+						return 35
+					case "HEAD":
+						return 36
+					case "OPTIONS":
+						return 37
+					case "PATCH":
+						return 38
+					case "POST":
+						return 39
+					case "PROPFIND":
+						return 40
+					case "PUT":
+						return 41
+					case "TRACE":
+						return 42
+					}
+					s.Allow("CONNECT", "DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PROPFIND", "PUT", "TRACE")
+				}
+				if r := matchNode8(s, xsearch); r >= 0 {
+					return r
+				}
+			}
+		}
+	}
+	return -1
+}
+
+func matchNode7(s *gen.State, search string) int {
+	if search != "" {
+		if p := gen.ParamEnd(search, '/', false); p >= 0 && strings.IndexByte(search[:p], '/') < 0 {
+			n := len(s.Values)
+			s.Values = append(s.Values, search[:p])
+			xsearch := search[p:]
+			if xsearch == "" {
+				switch s.Method {
+				case "GET":
+					return 32
+				}
+				s.Allow("GET")
+			}
+			s.Values = s.Values[:n]
+		}
+	}
+	return -1
+}
+
+func matchNode8(s *gen.State, search string) int {
+	if search != "" {
+		switch search[0] {
+		case '/':
+			xsearch := search[1:]
+			if xsearch == "" {
+				switch s.Method {
+				case "CONNECT":
+					return 43
+				case "DELETE":
+					return 44
+				case "GET":
+					return 45
+				case "HEAD":
+					return 46
+				case "OPTIONS":
+					return 47
+				case "PATCH":
+					return 48
+				case "POST":
+					return 49
+				case "PROPFIND":
+					return 50
+				case "PUT":
+					return 51
+				case "TRACE":
+					return 52
+				}
+				s.Allow("CONNECT", "DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PROPFIND", "PUT", "TRACE")
+			}
+			if r := matchNode9(s, xsearch); r >= 0 {
+				return r
+			}
+		}
+	}
+	return -1
+}
+
+func matchNode9(s *gen.State, search string) int {
+	s.Values = append(s.Values, search)
+	switch s.Method {
+	case "CONNECT":
+		return 53
+	case "DELETE":
+		return 54
+	case "GET":
+		return 55
+	case "HEAD":
+		return 56
+	case "OPTIONS":
+		return 57
+	case "PATCH":
+		return 58
+	case "POST":
+		return 59
+	case "PROPFIND":
+		return 60
+	case "PUT":
+		return 61
+	case "TRACE":
+		return 62
+	}
+	s.Allow("CONNECT", "DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PROPFIND", "PUT", "TRACE")
+	s.Pop()
+	return -1
+}
+
+func matchRouter2(rctx *gor.Context, s *gen.State, path string) gen.Result {
+	s.Reset()
+	route := matchNode10(s, path)
+	if route < 0 {
+		return s.Result(-1)
+	}
+	s.Record(rctx, &MatchRoutes[route])
+	return s.Result(route)
+}
+
+func matchNode10(s *gen.State, search string) int {
+	if search != "" {
+		switch search[0] {
+		case '/':
+			xsearch := search[1:]
+			if r := matchNode11(s, xsearch); r >= 0 {
+				return r
+			}
+		}
+	}
+	return -1
+}
+
+func matchNode11(s *gen.State, search string) int {
+	if search != "" {
+		switch search[0] {
+		case 'i':
+			if strings.HasPrefix(search, "items/") {
+				xsearch := search[6:]
+				if r := matchNode12(s, xsearch); r >= 0 {
+					return r
+				}
+			}
+		case 's':
+			if strings.HasPrefix(search, "status") {
+				xsearch := search[6:]
+				if xsearch == "" {
+					switch s.Method {
+					case "GET":
+						return 64
+					}
+					s.Allow("GET")
+				}
+			}
+		}
+	}
+	return -1
+}
+
+func matchNode12(s *gen.State, search string) int {
+	if search != "" {
+		if p := gen.ParamEnd(search, '/', true); p >= 0 && matchRexp0.MatchString(search[:p]) {
+			n := len(s.Values)
+			s.Values = append(s.Values, search[:p])
+			xsearch := search[p:]
+			if xsearch == "" {
+				switch s.Method {
+				case "PATCH":
+					return 63
+				}
+				s.Allow("PATCH")
+			}
+			s.Values = s.Values[:n]
+		}
+	}
+	return -1
+}
+
+func matchNode13(s *gen.State, search string) int {
+	if search != "" {
+		if p := gen.ParamEnd(search, '-', true); p >= 0 && gor.MatchParam("date", search[:p]) {
+			n := len(s.Values)
+			s.Values = append(s.Values, search[:p])
+			xsearch := search[p:]
+			if r := matchNode14(s, xsearch); r >= 0 {
+				return r
+			}
+			s.Values = s.Values[:n]
+		}
+		s.Values = append(s.Values, "")
+		if r := matchNode14(s, search); r >= 0 {
+			return r
+		}
+		s.Pop()
+	}
+	return -1
+}
+
+func matchNode14(s *gen.State, search string) int {
+	if search != "" {
+		switch search[0] {
+		case '-':
+			xsearch := search[1:]
+			if r := matchNode15(s, xsearch); r >= 0 {
+				return r
+			}
+		}
+	}
+	return -1
+}
+
+func matchNode15(s *gen.State, search string) int {
+	if search != "" {
+		if p := gen.ParamEnd(search, '/', false); p >= 0 && strings.IndexByte(search[:p], '/') < 0 {
+			n := len(s.Values)
+			s.Values = append(s.Values, search[:p])
+			xsearch := search[p:]
+			if xsearch == "" {
+				switch s.Method {
+				case "GET":
+					return 65
+				}
+				s.Allow("GET")
+			}
+			s.Values = s.Values[:n]
+		}
+	}
+	return -1
+}
+
+func matchNode16(s *gen.State, search string) int {
+	s.Values = append(s.Values, search)
+	switch s.Method {
+	case "PROPFIND":
+		return 66
+	}
+	s.Allow("PROPFIND")
+	s.Pop()
+	return -1
+}
+
+func matchNode17(s *gen.State, search string) int {
+	for p := strings.LastIndexByte(search, '/'); p > 0; p = strings.LastIndexByte(search[:p], '/') {
+		n := len(s.Values)
+		s.Values = append(s.Values, search[:p])
+		if r := matchNode18(s, search[p:]); r >= 0 {
+			return r
+		}
+		s.Values = s.Values[:n]
+	}
+	s.Values = append(s.Values, search)
+	switch s.Method {
+	case "GET":
+		return 68
+	}
+	s.Allow("GET")
+	if r := matchNode18(s, ""); r >= 0 {
+		return r
+	}
+	s.Pop()
+	return -1
+}
+
+func matchNode18(s *gen.State, search string) int {
+	if search != "" {
+		switch search[0] {
+		case '/':
+			if strings.HasPrefix(search, "/raw") {
+				xsearch := search[4:]
+				if xsearch == "" {
+					switch s.Method {
+					case "GET":
+						return 67
+					}
+					s.Allow("GET")
+				}
+			}
+		}
+	}
+	return -1
+}
+
+func matchNode19(s *gen.State, search string) int {
+	if search != "" {
+		switch search[0] {
+		case 'i':
+			if strings.HasPrefix(search, "ing") {
+				xsearch := search[3:]
+				if xsearch == "" {
+					switch s.Method {
+					case "GET":
+						return 79
+					case "POST":
+						return 80
+					}
+					s.Allow("GET", "POST")
+				}
+			}
+		case 'o':
+			if strings.HasPrefix(search, "ong") {
+				xsearch := search[3:]
+				if xsearch == "" {
+					switch s.Method {
+					case "GET":
+						return 81
+					}
+					s.Allow("GET")
+				}
+			}
+		}
+	}
+	return -1
+}
+
+func matchNode20(s *gen.State, search string) int {
+	if search != "" {
+		if p := gen.ParamEnd(search, '/', true); p >= 0 && gor.MatchParam("uint", search[:p]) {
+			n := len(s.Values)
+			s.Values = append(s.Values, search[:p])
+			xsearch := search[p:]
+			if xsearch == "" {
+				switch s.Method {
+				case "GET":
+					return 82
+				}
+				s.Allow("GET")
+			}
+			if r := matchNode21(s, xsearch); r >= 0 {
+				return r
+			}
+			s.Values = s.Values[:n]
+		}
+		s.Values = append(s.Values, "")
+		if r := matchNode21(s, search); r >= 0 {
+			return r
+		}
+		s.Pop()
+	}
+	return -1
+}
+
+func matchNode21(s *gen.State, search string) int {
+	if search != "" {
+		switch search[0] {
+		case '/':
+			xsearch := search[1:]
+			if r := matchNode22(s, xsearch); r >= 0 {
+				return r
+			}
+		}
+	}
+	return -1
+}
+
+func matchNode22(s *gen.State, search string) int {
+	if search != "" {
+		if p := gen.ParamEnd(search, '/', false); p >= 0 && strings.IndexByte(search[:p], '/') < 0 {
+			n := len(s.Values)
+			s.Values = append(s.Values, search[:p])
+			xsearch := search[p:]
+			if xsearch == "" {
+				switch s.Method {
+				case "GET":
+					return 83
+				}
+				s.Allow("GET")
+			}
+			s.Values = s.Values[:n]
+		}
+	}
+	return -1
+}
+
+func matchNode23(s *gen.State, search string) int {
+	if search != "" {
+		switch search[0] {
+		case 'e':
+			if strings.HasPrefix(search, "essions/") {
+				xsearch := search[8:]
+				if r := matchNode24(s, xsearch); r >= 0 {
+					return r
+				}
+			}
+		case 't':
+			if strings.HasPrefix(search, "tatic") {
+				xsearch := search[5:]
+				if xsearch == "" {
+					switch s.Method {
+					case "CONNECT":
+						return 85
+					case "DELETE":
+						return 86
+					case "GET":
+						return 87
+					case "HEAD":
+						return 88
+					case "OPTIONS":
+						return 89
+					case "PATCH":
+						return 90
+					case "POST":
+						return 91
+					case "PROPFIND":
+						return 92
+					case "PUT":
+						return 93
+					case "TRACE":
+						return 94
+					}
+					s.Allow("CONNECT", "DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PROPFIND", "PUT", "TRACE")
+				}
+				if r := matchNode25(s, xsearch); r >= 0 {
+					return r
+				}
+			}
+		}
+	}
+	return -1
+}
+
+func matchNode24(s *gen.State, search string) int {
+	if search != "" {
+		if p := gen.ParamEnd(search, '/', true); p >= 0 && gor.MatchParam("uuid", search[:p]) {
+			n := len(s.Values)
+			s.Values = append(s.Values, search[:p])
+			xsearch := search[p:]
+			if xsearch == "" {
+				switch s.Method {
+				case "DELETE":
+					return 84
+				}
+				s.Allow("DELETE")
+			}
+			s.Values = s.Values[:n]
+		}
+	}
+	return -1
+}
+
+func matchNode25(s *gen.State, search string) int {
+	if search != "" {
+		switch search[0] {
+		case '/':
+			xsearch := search[1:]
+			if xsearch == "" {
+				switch s.Method {
+				case "CONNECT":
+					return 95
+				case "DELETE":
+					return 96
+				case "GET":
+					return 97
+				case "HEAD":
+					return 98
+				case "OPTIONS":
+					return 99
+				case "PATCH":
+					return 100
+				case "POST":
+					return 101
+				case "PROPFIND":
+					return 102
+				case "PUT":
+					return 103
+				case "TRACE":
+					return 104
+				}
+				s.Allow("CONNECT", "DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PROPFIND", "PUT", "TRACE")
+			}
+			if r := matchNode26(s, xsearch); r >= 0 {
+				return r
+			}
+		}
+	}
+	return -1
+}
+
+func matchNode26(s *gen.State, search string) int {
+	s.Values = append(s.Values, search)
+	switch s.Method {
+	case "CONNECT":
+		return 105
+	case "DELETE":
+		return 106
+	case "GET":
+		return 107
+	case "HEAD":
+		return 108
+	case "OPTIONS":
+		return 109
+	case "PATCH":
+		return 110
+	case "POST":
+		return 111
+	case "PROPFIND":
+		return 112
+	case "PUT":
+		return 113
+	case "TRACE":
+		return 114
+	}
+	s.Allow("CONNECT", "DELETE", "GET", "HEAD", "OPTIONS", "PATCH", "POST", "PROPFIND", "PUT", "TRACE")
+	s.Pop()
+	return -1
+}
+
+func matchNode27(s *gen.State, search string) int {
+	if search != "" {
+		switch search[0] {
+		case '/':
+			xsearch := search[1:]
+			if r := matchNode28(s, xsearch); r >= 0 {
+				return r
+			}
+		}
+	}
+	return -1
+}
+
+func matchNode28(s *gen.State, search string) int {
+	if search != "" {
+		switch search[0] {
+		case 'm':
+			if strings.HasPrefix(search, "me") {
+				xsearch := search[2:]
+				if xsearch == "" {
+					switch s.Method {
+					case "GET":
+						return 117
+					}
+					s.Allow("GET")
+				}
+			}
+		}
+	}
+	if search != "" {
+		if p := gen.ParamEnd(search, '/', true); p >= 0 && gor.MatchParam("int", search[:p]) {
+			n := len(s.Values)
+			s.Values = append(s.Values, search[:p])
+			xsearch := search[p:]
+			if xsearch == "" {
+				switch s.Method {
+				case "GET":
+					return 118
+				case "PUT":
+					return 119
+				}
+				s.Allow("GET", "PUT")
+			}
+			if r := matchNode29(s, xsearch); r >= 0 {
+				return r
+			}
+			s.Values = s.Values[:n]
+		}
+		s.Values = append(s.Values, "")
+		if r := matchNode29(s, search); r >= 0 {
+			return r
+		}
+		s.Pop()
+	}
+	if search != "" {
+		if p := gen.ParamEnd(search, '/', false); p >= 0 && strings.IndexByte(search[:p], '/') < 0 {
+			n := len(s.Values)
+			s.Values = append(s.Values, search[:p])
+			xsearch := search[p:]
+			if xsearch == "" {
+				switch s.Method {
+				case "GET":
+					return 121
+				}
+				s.Allow("GET")
+			}
+			if r := matchNode31(s, xsearch); r >= 0 {
+				return r
+			}
+			s.Values = s.Values[:n]
+		}
+		s.Values = append(s.Values, "")
+		if r := matchNode31(s, search); r >= 0 {
+			return r
+		}
+		s.Pop()
+	}
+	return -1
+}
+
+func matchNode29(s *gen.State, search string) int {
+	if search != "" {
+		switch search[0] {
+		case '/':
+			if strings.HasPrefix(search, "/posts/") {
+				xsearch := search[7:]
+				if r := matchNode30(s, xsearch); r >= 0 {
+					return r
+				}
+			}
+		}
+	}
+	return -1
+}
+
+func matchNode30(s *gen.State, search string) int {
+	if search != "" {
+		if p := gen.ParamEnd(search, '/', true); p >= 0 && matchRexp1.MatchString(search[:p]) {
+			n := len(s.Values)
+			s.Values = append(s.Values, search[:p])
+			xsearch := search[p:]
+			if xsearch == "" {
+				switch s.Method {
+				case "GET":
+					return 120
+				}
+				s.Allow("GET")
+			}
+			s.Values = s.Values[:n]
+		}
+	}
+	return -1
+}
+
+func matchNode31(s *gen.State, search string) int {
+	if search != "" {
+		switch search[0] {
+		case '/':
+			if strings.HasPrefix(search, "/avatar.") {
+				xsearch := search[8:]
+				if r := matchNode32(s, xsearch); r >= 0 {
+					return r
+				}
+			}
+		}
+	}
+	return -1
+}
+
+func matchNode32(s *gen.State, search string) int {
+	if search != "" {
+		if p := gen.ParamEnd(search, '/', false); p >= 0 && strings.IndexByte(search[:p], '/') < 0 {
+			n := len(s.Values)
+			s.Values = append(s.Values, search[:p])
+			xsearch := search[p:]
+			if xsearch == "" {
+				switch s.Method {
+				case "GET":
+					return 122
+				}
+				s.Allow("GET")
+			}
+			s.Values = s.Values[:n]
+		}
+	}
+	return -1
+}
@@ -0,0 +1,51 @@
+// Package testroutes holds the router of the gen tests and the matcher generated from it.
+package testroutes
+
+import (
+	"net/http"
+
+	"github.com/pchchv/gor"
+)
+
+//go:generate go test ../.. -run TestGenerate -update
+
+// Router returns the router the matcher of the package is generated from.
+func Router() *gor.Mux {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := gor.NewRouter()
+	r.RegisterMethod("PROPFIND")
+	r.Get("/", h)
+	r.Get("/ping", h)
+	r.Post("/ping", h)
+	r.Get("/pong", h)
+	r.Handle("/health", http.HandlerFunc(h))
+
+	r.Get("/users", h)
+	r.Post("/users", h)
+	r.Get("/users/me", h)
+	r.Get("/users/{id:int}", h)
+	r.Put("/users/{id:int}", h)
+	r.Get("/users/{name}", h)
+	r.Get("/users/{id:int}/posts/{slug:[a-z-]+}", h)
+	r.Get("/users/{name}/avatar.{ext}", h)
+
+	r.Get("/files/{path...}", h)
+	r.Get("/files/*/raw", h)
+	r.Get("/reports/{year:uint}/{month?}", h)
+	r.Get("/archive/{date:date}-{n}", h)
+	r.Delete("/sessions/{id:uuid}", h)
+	r.Method("PROPFIND", "/dav/{path...}", http.HandlerFunc(h))
+
+	r.Route("/api", func(r gor.Router) {
+		r.Get("/", h)
+		r.Get("/items/{id}", h)
+		r.Route("/v1", func(r gor.Router) {
+			r.Get("/status", h)
+			r.Patch("/items/{id:[0-9a-f]+}", h)
+		})
+	})
+	r.Mount("/static", http.HandlerFunc(h))
+
+	return r
+}
@@ -0,0 +1,138 @@
+package gen
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pchchv/gor"
+)
+
+// Matcher is the signature of the generated matchers, e.g. `func Match(rctx *gor.Context, method, path string) gen.Result`.
+type Matcher func(rctx *gor.Context, method, path string) Result
+
+// Result is the result of the lookup of a generated matcher.
+type Result struct {
+	// Route is the index of the matched route in the route table of the matcher, or -1.
+	Route int
+
+	// MethodNotAllowed reports whether the path matches routes, but none of the request method.
+	MethodNotAllowed bool
+
+	// AllowedMethods are the sorted methods of the routes matching the path, see gor.Context.AllowedMethods.
+	AllowedMethods []string
+}
+
+// Route is a route of the route table of a generated matcher.
+type Route struct {
+	// Method is the HTTP method of the route.
+	Method string
+
+	// Pattern is the full routing pattern of the route, including the mount patterns, as reported by gor.WalkRoutes.
+	Pattern string
+
+	// RoutePattern is the routing pattern recorded in gor.Context.RoutePatterns by the router of the route.
+	RoutePattern string
+
+	// ParamKeys are the keys of the URL params recorded by the router of the route.
+	ParamKeys []string
+
+	// Mount reports whether a sub-router is mounted on the route, in which the lookup goes on.
+	Mount bool
+}
+
+// State is the state of a lookup of a generated matcher, only meant to be used by the generated code.
+type State struct {
+	// Method is the request method.
+	Method string
+
+	// Values are the values of the URL params matched in the current router.
+	Values []string
+
+	// keys of the URL params of the route matched in the current router
+	keys []string
+
+	// methods of the routes matching the path without the request method
+	allowed []string
+
+	methodNotAllowed bool
+}
+
+// Reset starts the lookup in a router.
+func (s *State) Reset() {
+	s.Values = s.Values[:0]
+	s.keys = nil
+	s.allowed = s.allowed[:0]
+	s.methodNotAllowed = false
+}
+
+// Allow flags the lookup with a route matching the path without a handler for the request method,
+// recording the methods of the route.
+func (s *State) Allow(methods ...string) {
+	s.methodNotAllowed = true
+	for _, m := range methods {
+		if !contains(s.allowed, m) {
+			s.allowed = append(s.allowed, m)
+		}
+	}
+}
+
+// Pop removes the last param value, if any.
+func (s *State) Pop() {
+	if len(s.Values) > 0 {
+		s.Values = s.Values[:len(s.Values)-1]
+	}
+}
+
+// Record records the URL params and the routing pattern of the route matched in the current router in the routing context.
+func (s *State) Record(rctx *gor.Context, rt *Route) {
+	s.keys = rt.ParamKeys
+	rctx.URLParams.Keys = append(rctx.URLParams.Keys, rt.ParamKeys...)
+	rctx.URLParams.Values = append(rctx.URLParams.Values, s.Values...)
+	if rt.RoutePattern != "" {
+		rctx.RoutePatterns = append(rctx.RoutePatterns, rt.RoutePattern)
+	}
+}
+
+// NextRoutePath returns the routing path of the sub-router mounted on the recorded route,
+// which is the value of its wildcard prefixed with a slash.
+func (s *State) NextRoutePath() string {
+	nx := len(s.keys) - 1
+	if nx >= 0 && s.keys[nx] == "*" && len(s.Values) > nx {
+		return "/" + s.Values[nx]
+	}
+	return "/"
+}
+
+// Result returns the result of the lookup in the current router.
+func (s *State) Result(route int) Result {
+	r := Result{Route: route, MethodNotAllowed: s.methodNotAllowed}
+	if len(s.allowed) > 0 {
+		r.AllowedMethods = append([]string(nil), s.allowed...)
+		sort.Strings(r.AllowedMethods)
+	}
+	return r
+}
+
+// ParamEnd returns the end of the value of a param or regexp node ending with the tail byte
+// at the start of the search path, or -1 if the node cannot match it.
+func ParamEnd(search string, tail byte, regexp bool) int {
+	p := strings.IndexByte(search, tail)
+	if p < 0 {
+		if tail != '/' {
+			return -1
+		}
+		p = len(search)
+	} else if regexp && p == 0 {
+		return -1
+	}
+	return p
+}
+
+func contains(ss []string, s string) bool {
+	for _, v := range ss {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
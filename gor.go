@@ -13,6 +13,16 @@ type Router interface {
 	// With built-in middleware modules to the endpoint handler.
 	With(middlewares ...func(http.Handler) http.Handler) Router
 
+	// Named returns an inline Router which registers its routes under `name`,
+	// so that their URLs can be built by name with URLFor.
+	Named(name string) Router
+
+	// Meta returns an inline Router which attaches the metadata `meta` to its routes.
+	Meta(meta Meta) Router
+
+	// Where returns an inline Router whose routes only serve the requests matching all of the predicates.
+	Where(predicates ...Predicate) Router
+
 	// Group adds a new inline Router along the current routing path,
 	// with a fresh middleware stack for the inline Router.
 	Group(fn func(r Router)) Router
@@ -20,6 +30,9 @@ type Router interface {
 	// Route mounts a sub Router on the `pattern` string.
 	Route(pattern string, fn func(r Router)) Router
 
+	// Host creates a new Router serving the requests whose host matches the host `pattern`.
+	Host(pattern string, fn func(r Router)) Router
+
 	// Mount attaches another http.Handler along the ./pattern/*
 	Mount(pattern string, h http.Handler)
 
@@ -27,6 +40,8 @@ type Router interface {
 	Handle(pattern string, h http.Handler)
 	// HandleFunc adds routes for a `pattern` that matches all HTTP methods.
 	HandleFunc(pattern string, h http.HandlerFunc)
+	// HandleStd adds routes for a net/http.ServeMux `pattern`, e.g. "GET /items/{id}".
+	HandleStd(pattern string, h http.Handler)
 
 	// Method adds routes for `pattern` which matches the HTTP method `method`.
 	Method(method, pattern string, h http.Handler)
@@ -70,6 +85,6 @@ type Routes interface {
 }
 
 // NewRouter returns a new Mux object that implements the Router interface.
-func NewRouter() *Mux {
-	return NewMux()
+func NewRouter(opts ...Option) *Mux {
+	return NewMux(opts...)
 }
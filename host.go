@@ -0,0 +1,236 @@
+package gor
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// hostRoute is a sub-router serving the requests with a host matching its pattern.
+type hostRoute struct {
+	// handler serving the matching requests, the sub-router with inline middlewares
+	handler http.Handler
+
+	// sub-router the routes of the host are registered on
+	router *Mux
+
+	// host pattern, as passed to Host()
+	pattern string
+
+	// labels of the host pattern, from left to right
+	labels []hostLabel
+}
+
+// hostLabel is a single dot-separated label of a host pattern.
+type hostLabel struct {
+	// typed or regexp matcher for regexp labels
+	match func(string) bool
+
+	// literal label for static labels or param key for param labels
+	value string
+
+	// label type: static, regexp, param or catchAll
+	ntype nodeType
+}
+
+// Host creates a new Mux with a fresh middleware stack, serving the requests whose host matches `pattern`.
+// Host patterns consist of dot-separated labels, each one being either a literal,
+// a `{param}`, a `{param:regexp}` or a `{param:type}` label, e.g. "{tenant}.example.com".
+// A leading "*" label matches one or more labels, e.g. "*.example.com".
+// Host params are added to the URL params of the request, along with the path params.
+// Ports are ignored and hosts are matched case-insensitively.
+// Exact hosts are matched first, then host patterns in registration order,
+// and requests matching no host are routed through the routes of the Mux itself.
+// Calling Host() again with the same pattern adds the routes to the existing host router.
+func (mx *Mux) Host(pattern string, fn func(r Router)) Router {
+	if fn == nil {
+		panic(fmt.Sprintf("gor: attempting to Host() a nil subrouter on '%s'", pattern))
+	}
+
+	m := mx.owner()
+	for _, hr := range m.hosts {
+		if hr.pattern == pattern {
+			fn(hr.router)
+			return hr.router
+		}
+	}
+
+	labels := parseHostPattern(pattern)
+
+	subRouter := NewRouter()
+	subRouter.methods.inherit(m.methods)
+	fn(subRouter)
+
+	// assign sub-Router's with the parent not found & method not allowed handler if not specified.
+	if subRouter.notFoundHandler == nil && m.notFoundHandler != nil {
+		subRouter.NotFound(m.notFoundHandler)
+	}
+	if subRouter.methodNotAllowedHandler == nil && m.methodNotAllowedHandler != nil {
+		subRouter.MethodNotAllowed(m.methodNotAllowedHandler)
+	}
+	m.inheritConfig(subRouter)
+
+	// build the computed routing handler, as the mux may have no other routes
+	if m.handler == nil {
+		m.updateRouteHandler()
+	}
+
+	var h http.Handler = subRouter
+	if mx.inline {
+		h = Chain(mx.middlewares...).Handler(subRouter)
+	}
+
+	hr := &hostRoute{handler: h, router: subRouter, pattern: pattern, labels: labels}
+	if isStaticHost(labels) {
+		// exact hosts take precedence over host patterns
+		n := 0
+		for n < len(m.hosts) && isStaticHost(m.hosts[n].labels) {
+			n++
+		}
+		m.hosts = append(m.hosts[:n], append([]*hostRoute{hr}, m.hosts[n:]...)...)
+	} else {
+		m.hosts = append(m.hosts, hr)
+	}
+
+	return subRouter
+}
+
+// findHost returns the host route matching the request host and records its params in the routing context.
+func (mx *Mux) findHost(rctx *Context, r *http.Request) *hostRoute {
+	host := r.Host
+	if host == "" {
+		host = r.URL.Host
+	}
+	host = strings.ToLower(strings.TrimSuffix(stripHostPort(host), "."))
+
+	for _, hr := range mx.hosts {
+		if hr.matchHost(rctx, host) {
+			return hr
+		}
+	}
+
+	return nil
+}
+
+// matchHost reports whether `host` matches the host pattern, adding the host params to the URL params.
+// Labels are matched from right to left, so that a leading wildcard can consume the remaining labels.
+func (hr *hostRoute) matchHost(rctx *Context, host string) bool {
+	n := len(rctx.URLParams.Keys)
+	if hr.matchLabels(rctx, host) {
+		return true
+	}
+
+	rctx.URLParams.Keys = rctx.URLParams.Keys[:n]
+	rctx.URLParams.Values = rctx.URLParams.Values[:n]
+	return false
+}
+
+func (hr *hostRoute) matchLabels(rctx *Context, host string) bool {
+	end := len(host)
+
+	for i := len(hr.labels) - 1; i >= 0; i-- {
+		l := hr.labels[i]
+		if l.ntype == ntCatchAll {
+			// the wildcard needs at least one more label
+			return end > 0
+		}
+
+		if end < 0 {
+			// the host has fewer labels than the pattern
+			return false
+		}
+
+		start := strings.LastIndexByte(host[:end], '.') + 1
+		label := host[start:end]
+		end = start - 1
+
+		switch {
+		case label == "":
+			return false
+		case l.ntype == ntStatic:
+			if label != l.value {
+				return false
+			}
+			continue
+		case l.ntype == ntRegexp && !l.match(label):
+			return false
+		}
+
+		rctx.URLParams.Add(l.value, label)
+	}
+
+	// all labels of the host must be consumed
+	return end < 0
+}
+
+// parseHostPattern splits a host pattern into its labels.
+func parseHostPattern(pattern string) []hostLabel {
+	if pattern == "" {
+		panic("gor: host pattern must not be empty")
+	}
+
+	parts := strings.Split(pattern, ".")
+	labels := make([]hostLabel, len(parts))
+	for i, part := range parts {
+		switch {
+		case part == "*":
+			if i != 0 {
+				panic(fmt.Sprintf("gor: wildcard '*' must be the first label of host pattern '%s'", pattern))
+			}
+			labels[i] = hostLabel{ntype: ntCatchAll}
+
+		case strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}"):
+			key := part[1 : len(part)-1]
+			if idx := strings.IndexByte(key, ':'); idx >= 0 {
+				rexpat := key[idx+1:]
+				key = key[:idx]
+
+				match, ok := lookupParamType(rexpat)
+				if !ok {
+					rex, err := regexp.Compile("^" + strings.TrimSuffix(strings.TrimPrefix(rexpat, "^"), "$") + "$")
+					if err != nil {
+						panic(fmt.Sprintf("gor: invalid regexp pattern '%s' in host param", rexpat))
+					}
+					match = rex.MatchString
+				}
+				labels[i] = hostLabel{ntype: ntRegexp, value: key, match: match}
+			} else {
+				labels[i] = hostLabel{ntype: ntParam, value: key}
+			}
+
+		case part == "" || strings.ContainsAny(part, "{}*"):
+			panic(fmt.Sprintf("gor: invalid label '%s' in host pattern '%s', params must span a whole label", part, pattern))
+
+		default:
+			labels[i] = hostLabel{ntype: ntStatic, value: strings.ToLower(part)}
+		}
+	}
+
+	return labels
+}
+
+func isStaticHost(labels []hostLabel) bool {
+	for _, l := range labels {
+		if l.ntype != ntStatic {
+			return false
+		}
+	}
+	return true
+}
+
+// stripHostPort removes the port, if any, from a host, also handling IPv6 literals.
+func stripHostPort(host string) string {
+	if strings.HasPrefix(host, "[") {
+		if i := strings.IndexByte(host, ']'); i > 0 {
+			return host[:i+1]
+		}
+		return host
+	}
+
+	if i := strings.LastIndexByte(host, ':'); i >= 0 {
+		return host[:i]
+	}
+
+	return host
+}
@@ -0,0 +1,135 @@
+package gor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+)
+
+func TestMuxHost(t *testing.T) {
+	r := NewRouter()
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("default"))
+	})
+
+	r.Host("admin.example.com", func(r Router) {
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("admin"))
+		})
+		r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(404)
+			w.Write([]byte("admin not found"))
+		})
+	})
+
+	r.Host("{tenant}.example.com", func(r Router) {
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("tenant:" + URLParam(r, "tenant")))
+		})
+		r.Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("tenant:" + URLParam(r, "tenant") + " user:" + URLParam(r, "id")))
+		})
+	})
+
+	r.Host("{id:int}.numbers.org", func(r Router) {
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("number:" + URLParam(r, "id")))
+		})
+	})
+
+	r.Host("*.static.org", func(r Router) {
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("static"))
+		})
+	})
+
+	tests := []struct {
+		host   string
+		method string
+		path   string
+		status int
+		body   string
+	}{
+		{"example.com", "GET", "/", 200, "default"},
+		{"admin.example.com:8080", "GET", "/", 200, "admin"},
+		{"ADMIN.Example.com", "GET", "/", 200, "admin"},
+		{"admin.example.com", "GET", "/nope", 404, "admin not found"},
+		{"acme.example.com", "GET", "/", 200, "tenant:acme"},
+		{"acme.example.com", "GET", "/users/7", 200, "tenant:acme user:7"},
+		{"acme.example.com", "POST", "/users/7", 405, ""},
+		{"acme.example.com", "GET", "/nope", 404, "404 page not found\n"},
+		{"a.b.example.com", "GET", "/users/7", 404, "404 page not found\n"},
+		{"42.numbers.org", "GET", "/", 200, "number:42"},
+		{"x42.numbers.org", "GET", "/", 200, "default"},
+		{"a.static.org", "GET", "/", 200, "static"},
+		{"a.b.static.org:443", "GET", "/", 200, "static"},
+		{"static.org", "GET", "/", 200, "default"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		req.Host = tt.host
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != tt.status || w.Body.String() != tt.body {
+			t.Fatalf("%s %s%s: expecting %d '%s', got %d '%s'", tt.method, tt.host, tt.path, tt.status, tt.body, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestMuxHostWalk(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := NewRouter()
+	r.Get("/", h)
+	r.Host("{tenant}.example.com", func(r Router) {
+		r.Get("/", h)
+		r.Route("/users", func(r Router) {
+			r.Post("/{id}", h)
+		})
+	})
+
+	var routes []string
+	err := Walk(r, func(method, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		routes = append(routes, method+" "+route)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(routes)
+
+	expected := []string{
+		"GET /",
+		"GET {tenant}.example.com/",
+		"POST {tenant}.example.com/users/{id}",
+	}
+	if !stringSliceEqual(routes, expected) {
+		t.Fatalf("expecting routes %v, got %v", expected, routes)
+	}
+
+	var hosts []string
+	for _, rt := range r.Routes() {
+		if rt.Host != "" {
+			hosts = append(hosts, rt.Host)
+		}
+	}
+	if !stringSliceEqual(hosts, []string{"{tenant}.example.com"}) {
+		t.Fatalf("unexpected host routes %v", hosts)
+	}
+}
+
+func TestHostPatternPanics(t *testing.T) {
+	for _, pattern := range []string{"", "api.*.example.com", "api-{env}.example.com", "a..b"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expected panic() for host pattern '%s'", pattern)
+				}
+			}()
+			NewRouter().Host(pattern, func(r Router) {})
+		}()
+	}
+}
@@ -0,0 +1,54 @@
+package gor
+
+// Meta is a set of arbitrary metadata attached to routes at registration time, e.g.
+//
+//	r.Meta(gor.Meta{"owner": "billing", "scopes": []string{"invoices:read"}}).Get("/invoices", h)
+//
+// The metadata of a route is reported by Routes() and WalkRoutes,
+// and exposed at request time by Context.RouteMeta once the route is matched.
+// Middlewares running before the routing, e.g. registered with Use(),
+// can look the metadata up with Match() on a fresh routing context.
+// Meta values must not be modified after registration.
+type Meta map[string]interface{}
+
+// Meta returns an inline Router which attaches `meta` to the routes registered on it.
+// Metadata of nested inline routers is merged, the inner values taking precedence.
+func (mx *Mux) Meta(meta Meta) Router {
+	im := mx.With().(*Mux)
+	im.routeMeta = mergeMeta(mx.routeMeta, meta)
+
+	return im
+}
+
+// mergeMeta merges the metadata maps into one, the later values taking precedence.
+// A single non-empty Meta is returned as is, without being copied.
+func mergeMeta(metas ...Meta) Meta {
+	var merged Meta
+	copied := false
+
+	for _, meta := range metas {
+		if len(meta) == 0 {
+			continue
+		}
+
+		if merged == nil {
+			merged = meta
+			continue
+		}
+
+		if !copied {
+			m := make(Meta, len(merged)+len(meta))
+			for k, v := range merged {
+				m[k] = v
+			}
+			merged = m
+			copied = true
+		}
+
+		for k, v := range meta {
+			merged[k] = v
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,96 @@
+package gor
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+)
+
+func TestMuxMeta(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		meta := RouteContext(r.Context()).RouteMeta()
+		fmt.Fprintf(w, "owner:%v scope:%v", meta["owner"], meta["scope"])
+	}
+
+	r := NewRouter()
+	r.Get("/plain", h)
+	r.Meta(Meta{"owner": "core"}).Get("/ping", h)
+	r.Meta(Meta{"owner": "billing"}).Group(func(r Router) {
+		r.Meta(Meta{"scope": "invoices:read"}).Get("/invoices", h)
+		r.Meta(Meta{"owner": "payments", "scope": "invoices:write"}).Post("/invoices", h)
+	})
+	r.Meta(Meta{"owner": "accounts"}).Route("/users", func(r Router) {
+		r.Meta(Meta{"scope": "users:read"}).Get("/{id}", h)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		method string
+		path   string
+		body   string
+	}{
+		{"GET", "/plain", "owner:<nil> scope:<nil>"},
+		{"GET", "/ping", "owner:core scope:<nil>"},
+		{"GET", "/invoices", "owner:billing scope:invoices:read"},
+		{"POST", "/invoices", "owner:payments scope:invoices:write"},
+		{"GET", "/users/1", "owner:accounts scope:users:read"},
+	}
+
+	for _, tt := range tests {
+		if _, body := testRequest(t, ts, tt.method, tt.path, nil); body != tt.body {
+			t.Fatalf("%s %s: expecting '%s', got '%s'", tt.method, tt.path, tt.body, body)
+		}
+	}
+
+	// metadata is available to middlewares through Match()
+	tctx := NewRouteContext()
+	if !r.Match(tctx, "GET", "/users/2") || tctx.RouteMeta()["scope"] != "users:read" {
+		t.Fatalf("expecting Match to record route metadata, got %v", tctx.RouteMeta())
+	}
+
+	var routes []string
+	err := WalkRoutes(r, func(rt RouteInfo) error {
+		routes = append(routes, fmt.Sprintf("%s %s %v %v", rt.Method, rt.Pattern, rt.Meta["owner"], rt.Meta["scope"]))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(routes)
+
+	expected := []string{
+		"GET /invoices billing invoices:read",
+		"GET /ping core <nil>",
+		"GET /plain <nil> <nil>",
+		"GET /users/{id} accounts users:read",
+		"POST /invoices payments invoices:write",
+	}
+	if !stringSliceEqual(routes, expected) {
+		t.Fatalf("expecting routes %v, got %v", expected, routes)
+	}
+}
+
+func TestMergeMeta(t *testing.T) {
+	a := Meta{"a": 1, "b": 1}
+	b := Meta{"b": 2}
+
+	if m := mergeMeta(nil, a, Meta{}); len(m) != 2 || m["a"] != 1 {
+		t.Fatalf("unexpected merged meta %v", m)
+	}
+
+	m := mergeMeta(a, b)
+	if m["a"] != 1 || m["b"] != 2 {
+		t.Fatalf("unexpected merged meta %v", m)
+	}
+	if a["b"] != 1 {
+		t.Fatal("mergeMeta must not modify its arguments")
+	}
+
+	if mergeMeta() != nil {
+		t.Fatal("expecting nil meta")
+	}
+}
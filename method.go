@@ -0,0 +1,188 @@
+package gor
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// customMethods numbers the custom methods, registered with RegisterMethod or Mux.RegisterMethod,
+// so that the routing trees of all of the routers map the method types to the same methods.
+var customMethods = struct {
+	types map[string]methodType
+	names map[methodType]string
+
+	// methods accepted by all of the routers, see RegisterMethod
+	global map[string]bool
+
+	sync.RWMutex
+}{
+	types:  make(map[string]methodType),
+	names:  make(map[methodType]string),
+	global: make(map[string]bool),
+}
+
+// RegisterMethod adds support for a custom HTTP method to all of the routers.
+// Prefer Mux.RegisterMethod, which scopes the method to a router and its sub-routers.
+func RegisterMethod(method string) {
+	if method = strings.ToUpper(method); method == "" {
+		return
+	}
+	if _, ok := methodMap[method]; ok {
+		return
+	}
+
+	customMethods.Lock()
+	customMethods.global[method] = true
+	customMethods.Unlock()
+
+	customMethodType(method)
+}
+
+// customMethodType returns the method type of a custom method, numbering the method on its first use.
+func customMethodType(method string) methodType {
+	customMethods.RLock()
+	mt, ok := customMethods.types[method]
+	customMethods.RUnlock()
+	if ok {
+		return mt
+	}
+
+	customMethods.Lock()
+	defer customMethods.Unlock()
+
+	if mt, ok := customMethods.types[method]; ok {
+		return mt
+	}
+	mt = mTRACE + methodType(len(customMethods.types)+1)<<2
+	customMethods.types[method] = mt
+	customMethods.names[mt] = method
+
+	return mt
+}
+
+// methodTypeString returns the method of a method type, or "" for the flags of the endpoints.
+func methodTypeString(method methodType) string {
+	for s, t := range methodMap {
+		if method == t {
+			return s
+		}
+	}
+
+	customMethods.RLock()
+	defer customMethods.RUnlock()
+	return customMethods.names[method]
+}
+
+// methodSet holds the custom methods registered on a router and its inline routers,
+// along with the set of the router it is mounted on, whose methods it inherits.
+type methodSet struct {
+	types  map[string]methodType
+	parent *methodSet
+
+	sync.RWMutex
+}
+
+// lookup returns the method type of a custom method registered on the router, on the routers it is mounted on,
+// or on all of the routers.
+func (s *methodSet) lookup(method string) (methodType, bool) {
+	for ; s != nil; s = s.parentSet() {
+		s.RLock()
+		mt, ok := s.types[method]
+		s.RUnlock()
+		if ok {
+			return mt, true
+		}
+	}
+
+	customMethods.RLock()
+	defer customMethods.RUnlock()
+	if customMethods.global[method] {
+		return customMethods.types[method], true
+	}
+	return 0, false
+}
+
+func (s *methodSet) parentSet() *methodSet {
+	s.RLock()
+	defer s.RUnlock()
+	return s.parent
+}
+
+// inherit makes the set inherit the methods of the set of the router it is mounted on,
+// unless it already inherits from a router.
+func (s *methodSet) inherit(parent *methodSet) {
+	for p := parent; p != nil; p = p.parentSet() {
+		if p == s {
+			return
+		}
+	}
+
+	s.Lock()
+	if s.parent == nil {
+		s.parent = parent
+	}
+	s.Unlock()
+}
+
+// names returns the sorted custom methods accepted by the router.
+func (s *methodSet) names() []string {
+	seen := make(map[string]bool)
+	for ; s != nil; s = s.parentSet() {
+		s.RLock()
+		for m := range s.types {
+			seen[m] = true
+		}
+		s.RUnlock()
+	}
+
+	customMethods.RLock()
+	for m := range customMethods.global {
+		seen[m] = true
+	}
+	customMethods.RUnlock()
+
+	names := make([]string, 0, len(seen))
+	for m := range seen {
+		names = append(names, m)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// RegisterMethod adds support for a custom HTTP method, e.g. "PROPFIND", to the router and to its sub-routers,
+// which can then register handlers for the method with Method and serve it with the routes of all of the methods.
+// The method is accepted by the other routers only when registered on them too.
+// The routers given to the functions of Route, Group and Host are *Mux values, e.g.
+//
+//	r.Route("/dav", func(r gor.Router) {
+//		r.(*gor.Mux).RegisterMethod("PROPFIND")
+//		r.Method("PROPFIND", "/*", propfind)
+//	})
+func (mx *Mux) RegisterMethod(method string) {
+	if method = strings.ToUpper(method); method == "" {
+		return
+	}
+	if _, ok := methodMap[method]; ok {
+		return
+	}
+
+	mt := customMethodType(method)
+
+	s := mx.owner().methods
+	s.Lock()
+	if s.types == nil {
+		s.types = make(map[string]methodType)
+	}
+	s.types[method] = mt
+	s.Unlock()
+}
+
+// methodType returns the method type of a standard method or of a custom method accepted by the router.
+func (mx *Mux) methodType(method string) (methodType, bool) {
+	if mt, ok := methodMap[method]; ok {
+		return mt, true
+	}
+	return mx.owner().methods.lookup(method)
+}
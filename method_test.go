@@ -0,0 +1,139 @@
+package gor
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestMuxRegisterMethod(t *testing.T) {
+	h := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(body))
+		}
+	}
+
+	dav := NewRouter()
+	dav.Handle("/any", h("any"))
+	dav.RegisterMethod("propfind")
+	dav.Method("PROPFIND", "/files/*", h("propfind"))
+	dav.Get("/files/*", h("get"))
+	dav.RegisterMethod("MKCOL")
+	dav.Route("/sub", func(r Router) {
+		r.Method("PROPFIND", "/", h("sub propfind"))
+		r.Method("MKCOL", "/", h("sub mkcol"))
+	})
+	dav.Group(func(r Router) {
+		r.(*Mux).RegisterMethod("LOCK")
+		r.Method("LOCK", "/files/*", h("lock"))
+	})
+
+	other := NewRouter()
+	other.Handle("/any", h("any"))
+	if err := other.TryMethod("PROPFIND", "/files/*", h("propfind")); !errors.Is(err, ErrUnsupportedMethod) {
+		t.Fatalf("expected ErrUnsupportedMethod on another router, got %v", err)
+	}
+
+	tests := []struct {
+		router *Mux
+		method string
+		path   string
+		status int
+		body   string
+		allow  string
+	}{
+		{dav, "PROPFIND", "/files/a", 200, "propfind", ""},
+		{dav, "GET", "/files/a", 200, "get", ""},
+		{dav, "MKCOL", "/files/a", 405, "", "GET, LOCK, PROPFIND"},
+		{dav, "PROPFIND", "/any", 200, "any", ""},
+		{dav, "MKCOL", "/any", 200, "any", ""},
+		{dav, "PROPFIND", "/sub", 200, "sub propfind", ""},
+		{dav, "MKCOL", "/sub", 200, "sub mkcol", ""},
+		{dav, "LOCK", "/files/a", 200, "lock", ""},
+		{dav, "LOCK", "/any", 200, "any", ""},
+		{dav, "UNLOCK", "/any", 405, "", ""},
+		{other, "PROPFIND", "/any", 405, "", ""},
+		{other, "GET", "/any", 200, "any", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.method+" "+tt.path, func(t *testing.T) {
+			resp, body := testHandler(t, tt.router, tt.method, tt.path, nil)
+			if resp.StatusCode != tt.status || body != tt.body && tt.status == 200 {
+				t.Fatalf("expected %d %q, got %d %q", tt.status, tt.body, resp.StatusCode, body)
+			}
+			if allow := resp.Header.Get("Allow"); tt.allow != "" && allow != tt.allow {
+				t.Fatalf("expected Allow %q, got %q", tt.allow, allow)
+			}
+		})
+	}
+
+	if !dav.Match(NewRouteContext(), "MKCOL", "/sub") || other.Match(NewRouteContext(), "MKCOL", "/any") {
+		t.Fatalf("unexpected Match of the custom methods")
+	}
+
+	routes := make(map[string]bool)
+	if err := Walk(dav, func(method, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		routes[method+" "+route] = true
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for _, rt := range []string{"PROPFIND /any", "MKCOL /any", "GET /any", "PROPFIND /files/*", "PROPFIND /sub/", "MKCOL /sub/"} {
+		if !routes[rt] {
+			t.Errorf("missing walked route %s in %v", rt, routes)
+		}
+	}
+	if routes["MKCOL /files/*"] {
+		t.Errorf("unexpected walked route MKCOL /files/*")
+	}
+
+	if err := dav.RemoveRoute("propfind", "/files/*"); err != nil {
+		t.Fatal(err)
+	}
+	if resp, _ := testHandler(t, dav, "PROPFIND", "/files/a", nil); resp.StatusCode != 405 {
+		t.Fatalf("expected 405 after removing the route, got %d", resp.StatusCode)
+	}
+}
+
+func TestMuxRegisterMethodUnlimited(t *testing.T) {
+	r := NewRouter()
+	for i := 0; i < 200; i++ {
+		method := fmt.Sprintf("M%d", i)
+		r.RegisterMethod(method)
+		r.MethodFunc(method, "/", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(r.Method))
+		})
+	}
+
+	for _, method := range []string{"M0", "M63", "M64", "M199"} {
+		if _, body := testHandler(t, r, method, "/", nil); body != method {
+			t.Fatalf("expected %s, got %q", method, body)
+		}
+	}
+}
+
+func TestMuxRegisterMethodConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			r := NewRouter()
+			sub := NewRouter()
+			r.Mount("/sub", sub)
+			for j := 0; j < 20; j++ {
+				method := fmt.Sprintf("C%d", (i+j)%10)
+				r.RegisterMethod(method)
+				sub.MethodFunc(method, "/", func(w http.ResponseWriter, r *http.Request) {})
+				if resp, _ := testHandler(t, r, method, "/sub/", nil); resp.StatusCode != 200 {
+					t.Errorf("%s /sub/: expected 200, got %d", method, resp.StatusCode)
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
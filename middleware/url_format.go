@@ -14,6 +14,7 @@ var URLFormatCtxKey = &contextKey{"URLFormat"}
 // URLFormat is a middleware that parses the url extension from the request path and stores it
 // in context as a string under the `middleware.URLFormatCtxKey`.
 // The middleware trims the suffix from the routing path and continues routing.
+// The format is also recorded as the URLFormat of the routing context, selecting the representation served by gor.Negotiate.
 // Routers must not include a url parameter for the suffix when using this middleware.
 func URLFormat(next http.Handler) http.Handler {
 	fn := func(w http.ResponseWriter, r *http.Request) {
@@ -34,6 +35,7 @@ func URLFormat(next http.Handler) http.Handler {
 				format = path[idx+1:]
 
 				rctx.RoutePath = path[:idx]
+				rctx.URLFormat = format
 			}
 		}
 
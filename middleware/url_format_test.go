@@ -70,3 +70,32 @@ func TestURLFormatInSubRouter(t *testing.T) {
 		t.Fatalf(resp)
 	}
 }
+
+func TestURLFormatNegotiate(t *testing.T) {
+	r := gor.NewRouter()
+	r.Use(URLFormat)
+
+	r.Method("GET", "/report", gor.Negotiate{
+		"application/json": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("json"))
+		}),
+		"text/csv": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("csv"))
+		}),
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	if _, resp := testRequest(t, ts, "GET", "/report.csv", nil); resp != "csv" {
+		t.Fatalf(resp)
+	}
+
+	if _, resp := testRequest(t, ts, "GET", "/report", nil); resp != "json" {
+		t.Fatalf(resp)
+	}
+
+	if _, resp := testRequest(t, ts, "GET", "/report.xml", nil); resp != "Not Acceptable, available representations: application/json, text/csv\n" {
+		t.Fatalf(resp)
+	}
+}
@@ -1,7 +1,6 @@
 package gor
 
 import (
-	"context"
 	"fmt"
 	"net/http"
 	"strings"
@@ -18,7 +17,7 @@ type Mux struct {
 	handler http.Handler
 
 	// The radix trie router
-	tree *node
+	tree *routeTree
 
 	// Custom method not allowed handler
 	methodNotAllowedHandler http.HandlerFunc
@@ -32,6 +31,27 @@ type Mux struct {
 	// Custom route not found handler
 	notFoundHandler http.HandlerFunc
 
+	// Route names registered on this mux, mapped to their routing patterns
+	names map[string]string
+
+	// Name given to the routes registered through an inline mux
+	routeName string
+
+	// Metadata attached to the routes registered through an inline mux
+	routeMeta Meta
+
+	// Predicates the requests must match to be served by the routes registered through an inline mux
+	predicates []Predicate
+
+	// Routing options, shared with the sub-routers which are not configured
+	cfg *config
+
+	// Host routers, matched against the request host before the routing tree
+	hosts []*hostRoute
+
+	// Custom methods registered on the mux, shared with its inline muxes
+	methods *methodSet
+
 	// The middleware stack
 	middlewares []func(http.Handler) http.Handler
 
@@ -43,11 +63,20 @@ type Mux struct {
 var _ Router = &Mux{}
 
 // NewMux returns a newly initialized Mux object that implements the Router interface.
-func NewMux() *Mux {
-	mux := &Mux{tree: &node{}, pool: &sync.Pool{}}
+// The options configure the routing behavior of the Mux and of the sub-routers mounted on it,
+// unless they are configured with their own options.
+func NewMux(opts ...Option) *Mux {
+	mux := &Mux{tree: newRouteTree(), pool: &sync.Pool{}, methods: &methodSet{}}
+
+	if len(opts) > 0 {
+		mux.cfg = &config{}
+		for _, opt := range opts {
+			opt(mux.cfg)
+		}
+	}
 
 	mux.pool.New = func() interface{} {
-		return NewRouteContext()
+		return &requestContext{Context: *newPooledRouteContext()}
 	}
 
 	return mux
@@ -64,7 +93,10 @@ func (mx *Mux) NotFoundHandler() http.HandlerFunc {
 
 // ServeHTTP is the only method of the http.Handler interface that
 // makes Mux compatible with the standard library.
-// It uses sync.Pool to get and reuse routing contexts for each request.
+// The routing context of the request is its context, wrapping the context the request had before.
+// The routing context and the copy of the request served to the handlers are pooled and reused once the request
+// is finished, so they must not be used after the handler returns, e.g. by a goroutine: copy the values it needs.
+// The routing contexts a context is derived from during the request, e.g. with context.WithTimeout, are not reused.
 func (mx *Mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// Ensure the mux has some routes defined on the mux
 	if mx.handler == nil {
@@ -79,21 +111,24 @@ func (mx *Mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Fetch a RouteContext object from the sync pool and call the computable
-	// mx.handler consisting of mx.middlewares + mx.routeHTTP. When the request is finished,
-	// reset the routing context and put it back in the pool for reuse from another request.
-	rctx = mx.pool.Get().(*Context)
-	rctx.Reset()
-	rctx.Routes = mx
-	rctx.parentCtx = r.Context()
+	// Fetch a routing context along with a request copy from the sync pool and call the computable
+	// mx.handler consisting of mx.middlewares + mx.routeHTTP. The routing context is the context of the request copy,
+	// which saves the allocation of a context.WithValue, and the copy is made in place, which saves its allocation.
+	// When the request is finished, reset them and put them back in the pool for reuse from another request.
+	rc := mx.pool.Get().(*requestContext)
+	rc.Routes = mx
+	rc.parentCtx = r.Context()
+	rc.req = *r.WithContext(&rc.Context)
 
-	// r.WithContext() causes 2 allocations and context.WithValue() causes 1 allocation
-	r = r.WithContext(context.WithValue(r.Context(), RouteCtxKey, rctx))
+	mx.handler.ServeHTTP(w, &rc.req)
 
-	// Serve the request and after its done
-	// return the request context back to the synchronization pool
-	mx.handler.ServeHTTP(w, r)
-	mx.pool.Put(rctx)
+	// the contexts derived from the routing context may still use it
+	if rc.derived {
+		return
+	}
+	rc.Reset()
+	rc.req = http.Request{}
+	mx.pool.Put(rc)
 }
 
 // Use appends a middleware handler to the Mux middleware stack.
@@ -101,21 +136,38 @@ func (mx *Mux) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 // which provides opportunity to respond early, change the course of the request execution,
 // or set request-scoped values for the next http.Handler.
 func (mx *Mux) Use(middlewares ...func(http.Handler) http.Handler) {
+	if err := mx.TryUse(middlewares...); err != nil {
+		panic(err)
+	}
+}
+
+// TryUse is like Use, but returns a *RouteError wrapping ErrMiddlewareOrder
+// instead of panicking when routes are already defined on the mux.
+func (mx *Mux) TryUse(middlewares ...func(http.Handler) http.Handler) error {
 	if mx.handler != nil {
-		panic("gor: all middlewares must be defined before routes on a mux")
+		return routeError(ErrMiddlewareOrder, "", "", "all middlewares must be defined before routes on a mux")
 	}
 
 	mx.middlewares = append(mx.middlewares, middlewares...)
+	return nil
 }
 
 // Method adds a route `pattern` that matches `method` http method to execute the `handler` http.Handler.
 func (mx *Mux) Method(method, pattern string, handler http.Handler) {
-	m, ok := methodMap[strings.ToUpper(method)]
+	if err := mx.TryMethod(method, pattern, handler); err != nil {
+		panic(err)
+	}
+}
+
+// TryMethod is like Method, but returns a *RouteError instead of panicking
+// for an unsupported method or an invalid routing pattern.
+func (mx *Mux) TryMethod(method, pattern string, handler http.Handler) error {
+	m, ok := mx.methodType(strings.ToUpper(method))
 	if !ok {
-		panic(fmt.Sprintf("gor: '%s' http method is not supported.", method))
+		return routeError(ErrUnsupportedMethod, method, pattern, "'%s' http method is not supported.", method)
 	}
 
-	mx.handle(m, pattern, handler)
+	return mx.tryHandle(m, pattern, handler, nil)
 }
 
 // MethodFunc adds a route `pattern` that matches `method` http method to execute the `handlerFn` http.HandlerFunc.
@@ -233,18 +285,39 @@ func (mx *Mux) With(middlewares ...func(http.Handler) http.Handler) Router {
 	mws = append(mws, middlewares...)
 
 	im := &Mux{
-		pool: mx.pool, inline: true, parent: mx, tree: mx.tree, middlewares: mws,
+		pool: mx.pool, inline: true, parent: mx, tree: mx.tree, middlewares: mws, routeName: mx.routeName, routeMeta: mx.routeMeta, predicates: mx.predicates, cfg: mx.cfg,
 		notFoundHandler: mx.notFoundHandler, methodNotAllowedHandler: mx.methodNotAllowedHandler,
 	}
 
 	return im
 }
 
+// Named returns an inline Router which registers its routes under `name`,
+// so that their URLs can later be built with URLFor.
+func (mx *Mux) Named(name string) Router {
+	if name == "" {
+		panic("gor: attempting to Named() a route with an empty name")
+	}
+
+	im := mx.With().(*Mux)
+	im.routeName = name
+
+	return im
+}
+
 // Handle adds a `pattern` route matching any http method to execute `handler` http.Handler.
+// Adding a route while the mux is serving requests requires the WithDynamicRoutes option: otherwise the routing tree
+// and its index of the static routes, a map read by every request, are changed in place, which crashes the program
+// with a concurrent map read and map write.
 func (mx *Mux) Handle(pattern string, handler http.Handler) {
 	mx.handle(mALL, pattern, handler)
 }
 
+// TryHandle is like Handle, but returns a *RouteError instead of panicking for an invalid routing pattern.
+func (mx *Mux) TryHandle(pattern string, handler http.Handler) error {
+	return mx.tryHandle(mALL, pattern, handler, nil)
+}
+
 // HandleFunc adds a `pattern` route that matches any http method to. execute `handlerFn` http.HandlerFunc.
 func (mx *Mux) HandleFunc(pattern string, handlerFn http.HandlerFunc) {
 	mx.handle(mALL, pattern, handlerFn)
@@ -269,7 +342,9 @@ func (mx *Mux) Route(pattern string, fn func(r Router)) Router {
 		panic(fmt.Sprintf("gor: attempting to Route() a nil subrouter on '%s'", pattern))
 	}
 
+	// the routes of the sub-router may use the custom methods of the mux
 	subRouter := NewRouter()
+	subRouter.methods.inherit(mx.owner().methods)
 	fn(subRouter)
 	mx.Mount(pattern, subRouter)
 
@@ -282,13 +357,33 @@ func (mx *Mux) Route(pattern string, fn func(r Router)) Router {
 // which in most cases is another gor.Router.
 // As a result, if you define two Mount() routes on the same pattern, mount will cause a panic.
 func (mx *Mux) Mount(pattern string, handler http.Handler) {
+	if err := mx.TryMount(pattern, handler); err != nil {
+		panic(err)
+	}
+}
+
+// TryMount is like Mount, but returns a *RouteError instead of panicking
+// for a nil handler, an invalid routing pattern or a pattern mounted on an existing path.
+func (mx *Mux) TryMount(pattern string, handler http.Handler) error {
 	if handler == nil {
-		panic(fmt.Sprintf("gor: attempting to Mount() a nil handler on '%s'", pattern))
+		return routeError(ErrNilHandler, "", pattern, "attempting to Mount() a nil handler on '%s'", pattern)
+	}
+
+	if err := patValidate(pattern); err != nil {
+		return err
+	}
+
+	mountPattern := pattern + "*"
+	if pattern[len(pattern)-1] != '/' {
+		mountPattern = pattern + "/*"
+	}
+	if err := patValidate(mountPattern); err != nil {
+		return err
 	}
 
 	// provide runtime safety for ensuring a pattern isn't mounted on an existing routing pattern.
-	if mx.tree.findPattern(pattern+"*") || mx.tree.findPattern(pattern+"/*") {
-		panic(fmt.Sprintf("gor: attempting to Mount() a handler on an existing path, '%s'", pattern))
+	if root := mx.tree.load(); root.findPattern(pattern+"*") || root.findPattern(pattern+"/*") {
+		return routeError(ErrMountConflict, "", pattern, "attempting to Mount() a handler on an existing path, '%s'", pattern)
 	}
 
 	// assign sub-Router's with the parent not found & method not allowed handler if not specified.
@@ -299,12 +394,18 @@ func (mx *Mux) Mount(pattern string, handler http.Handler) {
 	if ok && subr.methodNotAllowedHandler == nil && mx.methodNotAllowedHandler != nil {
 		subr.MethodNotAllowed(mx.methodNotAllowedHandler)
 	}
+	if ok {
+		mx.owner().inheritConfig(subr)
+	}
 
 	mountHandler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		rctx := RouteContext(r.Context())
 
 		// shift the url path past the previous subrouter
-		rctx.RoutePath = mx.nextRoutePath(rctx)
+		rctx.RoutePath = mx.nextRoutePath(rctx, routePath(rctx, r))
+		if rctx.trace != nil {
+			rctx.trace.mount(rctx.routePattern, rctx.RoutePath)
+		}
 
 		// reset the wildcard URLParam which connects the subrouter
 		n := len(rctx.URLParams.Keys) - 1
@@ -312,12 +413,17 @@ func (mx *Mux) Mount(pattern string, handler http.Handler) {
 			rctx.URLParams.Values[n] = ""
 		}
 
+		rctx.mounted = true
 		handler.ServeHTTP(w, r)
 	})
 
-	if pattern == "" || pattern[len(pattern)-1] != '/' {
-		mx.handle(mALL|mSTUB, pattern, mountHandler)
-		mx.handle(mALL|mSTUB, pattern+"/", mountHandler)
+	if pattern[len(pattern)-1] != '/' {
+		if err := mx.tryHandle(mALL|mSTUB, pattern, mountHandler, nil); err != nil {
+			return err
+		}
+		if err := mx.tryHandle(mALL|mSTUB, pattern+"/", mountHandler, nil); err != nil {
+			return err
+		}
 		pattern += "/"
 	}
 
@@ -326,16 +432,107 @@ func (mx *Mux) Mount(pattern string, handler http.Handler) {
 	if subroutes != nil {
 		method |= mSTUB
 	}
-	n := mx.handle(method, pattern+"*", mountHandler)
 
-	if subroutes != nil {
-		n.subroutes = subroutes
+	return mx.tryHandle(method, pattern+"*", mountHandler, subroutes)
+}
+
+// RemoveRoute removes the route registered with the `pattern` for the `method` http method,
+// or for all of the methods with the "*" method, returning a *RouteError wrapping ErrRouteNotFound
+// if there is no such route. Removing a route while the mux is serving requests requires the WithDynamicRoutes option.
+func (mx *Mux) RemoveRoute(method, pattern string) error {
+	mt := mALL | mSTUB
+	if method != "*" {
+		m, ok := mx.methodType(strings.ToUpper(method))
+		if !ok {
+			return routeError(ErrUnsupportedMethod, method, pattern, "'%s' http method is not supported.", method)
+		}
+		mt = m
+	}
+
+	if err := patValidate(pattern); err != nil {
+		return err
+	}
+
+	removed := false
+	mx.tree.update(mx.dynamic(), func(root *node) bool {
+		removed = root.removeRoute(mt, pattern)
+		return removed
+	})
+
+	if !removed {
+		return routeError(ErrRouteNotFound, method, pattern, "no '%s' route registered for '%s'", method, pattern)
 	}
+	return nil
+}
+
+// Unmount removes the handler or sub-router mounted along the `pattern` by Mount,
+// returning a *RouteError wrapping ErrRouteNotFound if there is none.
+// Unmounting while the mux is serving requests requires the WithDynamicRoutes option.
+func (mx *Mux) Unmount(pattern string) error {
+	if err := patValidate(pattern); err != nil {
+		return err
+	}
+
+	mountPattern := pattern + "*"
+	if pattern[len(pattern)-1] != '/' {
+		mountPattern = pattern + "/*"
+	}
+	if err := patValidate(mountPattern); err != nil {
+		return err
+	}
+
+	removed := false
+	mx.tree.update(mx.dynamic(), func(root *node) bool {
+		if removed = root.removeRoute(mALL|mSTUB, mountPattern); !removed {
+			return false
+		}
+
+		if pattern[len(pattern)-1] != '/' {
+			root.removeRoute(mALL|mSTUB, pattern)
+			root.removeRoute(mALL|mSTUB, pattern+"/")
+		}
+		return true
+	})
+
+	if !removed {
+		return routeError(ErrRouteNotFound, "", pattern, "no handler mounted on '%s'", pattern)
+	}
+	return nil
 }
 
 // Routes returns a slice of routing information from the tree, useful for traversing available routes of a router.
+// Host routers are reported as sub-routers mounted on "/*" along with their host pattern.
 func (mx *Mux) Routes() []Route {
-	return mx.tree.routes()
+	routes := mx.tree.load().routes()
+
+	// the routes of all of the methods serve the custom methods without a handler of their own
+	if custom := mx.owner().methods.names(); len(custom) > 0 {
+		for _, rt := range routes {
+			h := rt.Handlers["*"]
+			if h == nil {
+				continue
+			}
+			for _, m := range custom {
+				if _, ok := rt.Handlers[m]; ok {
+					continue
+				}
+				rt.Handlers[m] = h
+				if meta := rt.Meta["*"]; meta != nil {
+					rt.Meta[m] = meta
+				}
+			}
+		}
+	}
+
+	for _, hr := range mx.hosts {
+		routes = append(routes, Route{
+			SubRoutes: hr.router,
+			Handlers:  map[string]http.Handler{"*": hr.handler},
+			Pattern:   "/*",
+			Host:      hr.pattern,
+		})
+	}
+	return routes
 }
 
 // Middlewares returns a slice of middleware handler functions.
@@ -347,34 +544,70 @@ func (mx *Mux) Middlewares() Middlewares {
 // This is similar to routing an http request, but without executing the handler afterwards.
 // The *Context state is updated  at runtime, so manage the state carefully or make a NewRouteContext().
 func (mx *Mux) Match(rctx *Context, method, path string) bool {
-	m, ok := methodMap[method]
+	m, ok := mx.methodType(method)
 	if !ok {
 		return false
 	}
 
-	node, _, h := mx.tree.FindRoute(rctx, m, path)
+	node, h, _ := mx.findRoute(rctx, m, path)
 
 	if node != nil && node.subroutes != nil {
-		rctx.RoutePath = mx.nextRoutePath(rctx)
+		rctx.RoutePath = mx.nextRoutePath(rctx, path)
 		return node.subroutes.Match(rctx, method, rctx.RoutePath)
 	}
 
 	return h != nil
 }
 
-func (mx *Mux) nextRoutePath(rctx *Context) string {
+// routePath returns the path a request is routed on by a router: the RoutePath of the routing context
+// when routed by a sub-router, or else the path of the request URL.
+func routePath(rctx *Context, r *http.Request) string {
+	if rctx.RoutePath != "" {
+		return rctx.RoutePath
+	}
+	if r.URL.RawPath != "" {
+		return r.URL.RawPath
+	}
+	if r.URL.Path != "" {
+		return r.URL.Path
+	}
+	return "/"
+}
+
+// nextRoutePath returns the routing path of the sub-router mounted on the route matching `path`,
+// which is the value of the wildcard prefixed with a slash, sliced from `path` when possible to save an allocation.
+func (mx *Mux) nextRoutePath(rctx *Context, path string) string {
 	routePath := "/"
 	nx := len(rctx.routeParams.Keys) - 1 // index of last param in list
 	if nx >= 0 && rctx.routeParams.Keys[nx] == "*" && len(rctx.routeParams.Values) > nx {
-		routePath = "/" + rctx.routeParams.Values[nx]
+		value := rctx.routeParams.Values[nx]
+		if i := len(path) - len(value) - 1; i >= 0 && path[i] == '/' && path[i+1:] == value {
+			return path[i:]
+		}
+		routePath = "/" + value
 	}
 	return routePath
 }
 
 // handle registers http.Handler in the routing tree for a particular http method and routing pattern.
-func (mx *Mux) handle(method methodType, pattern string, handler http.Handler) *node {
-	if len(pattern) == 0 || pattern[0] != '/' {
-		panic(fmt.Sprintf("gor: routing pattern must begin with '/' in '%s'", pattern))
+func (mx *Mux) handle(method methodType, pattern string, handler http.Handler) {
+	if err := mx.tryHandle(method, pattern, handler, nil); err != nil {
+		panic(err)
+	}
+}
+
+// tryHandle validates the routing pattern and the route name before adding the route to the tree,
+// along with the sub-router mounted on the route, if any.
+func (mx *Mux) tryHandle(method methodType, pattern string, handler http.Handler, subroutes Routes) error {
+	if err := patValidate(pattern); err != nil {
+		return err
+	}
+
+	// record the route name, skipping the stub routes added by Mount()
+	if mx.routeName != "" && method&mSTUB == 0 {
+		if err := mx.owner().setRouteName(mx.routeName, pattern); err != nil {
+			return err
+		}
 	}
 
 	// build the computed routing handler for this routing pattern
@@ -391,8 +624,45 @@ func (mx *Mux) handle(method methodType, pattern string, handler http.Handler) *
 		h = handler
 	}
 
-	// add the endpoint to the tree and return the node
-	return mx.tree.InsertRoute(method, pattern, h)
+	// dispatch the requests matching the predicates of the inline mux to the handler,
+	// which keeps the route metadata of its own
+	if len(mx.predicates) > 0 {
+		h = &variantHandler{variants: []routeVariant{{predicates: mx.predicates, handler: h, meta: mx.routeMeta}}}
+	}
+
+	// add the endpoints to the tree
+	mx.tree.update(mx.dynamic(), func(root *node) bool {
+		nodes := root.insertRoutes(method, pattern, h)
+
+		if mx.routeMeta != nil && len(mx.predicates) == 0 {
+			for _, n := range nodes {
+				n.setEndpointMeta(method, mx.routeMeta)
+			}
+		}
+
+		if subroutes != nil {
+			nodes[len(nodes)-1].subroutes = subroutes
+		}
+
+		return true
+	})
+
+	return nil
+}
+
+// dynamic reports whether the routes of the mux can be changed while it is serving requests.
+func (mx *Mux) dynamic() bool {
+	cfg := mx.owner().cfg
+	return cfg != nil && cfg.dynamic
+}
+
+// owner returns the mux owning the routing tree, i.e. the closest non-inline mux.
+func (mx *Mux) owner() *Mux {
+	m := mx
+	for m.inline && m.parent != nil {
+		m = m.parent
+	}
+	return m
 }
 
 // routeHTTP routes a http.Request through the Mux routing tree to serve the matching handler for a particular http method.
@@ -401,16 +671,13 @@ func (mx *Mux) routeHTTP(w http.ResponseWriter, r *http.Request) {
 	rctx := r.Context().Value(RouteCtxKey).(*Context)
 
 	// the request routing path
-	routePath := rctx.RoutePath
-	if routePath == "" {
-		if r.URL.RawPath != "" {
-			routePath = r.URL.RawPath
-		} else {
-			routePath = r.URL.Path
-		}
+	routePath := routePath(rctx, r)
 
-		if routePath == "" {
-			routePath = "/"
+	// route the request through the host router matching the request host
+	if len(mx.hosts) > 0 {
+		if hr := mx.findHost(rctx, r); hr != nil {
+			hr.handler.ServeHTTP(w, r)
+			return
 		}
 	}
 
@@ -419,24 +686,106 @@ func (mx *Mux) routeHTTP(w http.ResponseWriter, r *http.Request) {
 		rctx.RouteMethod = r.Method
 	}
 
-	method, ok := methodMap[rctx.RouteMethod]
+	method, ok := mx.methodType(rctx.RouteMethod)
 	if !ok {
 		mx.MethodNotAllowedHandler().ServeHTTP(w, r)
 		return
 	}
 
+	// trace the route lookup in a response header
+	if mx.cfg != nil && mx.cfg.traceHeader != "" && rctx.trace == nil {
+		rctx.trace = &Explanation{Method: r.Method, Path: routePath}
+	}
+
 	// find the route
-	if _, _, h := mx.tree.FindRoute(rctx, method, routePath); h != nil {
+	_, h, redirectPath := mx.findRoute(rctx, method, routePath)
+	if rctx.trace != nil && mx.cfg != nil && mx.cfg.traceHeader != "" {
+		w.Header().Set(mx.cfg.traceHeader, rctx.trace.compact())
+	}
+	if vh, ok := h.(*variantHandler); ok {
+		v := vh.variant(r)
+		if v == nil {
+			mx.NotFoundHandler().ServeHTTP(w, r)
+			return
+		}
+		h = v.handler
+		if v.meta != nil {
+			rctx.routeMetas = append(rctx.routeMetas, v.meta)
+		}
+	}
+	if h != nil {
+		if len(rctx.URLParams.Keys) > 0 {
+			setPathValues(r, rctx)
+		}
+		rctx.mounted = false
 		h.ServeHTTP(w, r)
 		return
 	}
+	if redirectPath != "" {
+		redirectRoutePath(w, r, routePath, redirectPath)
+		return
+	}
 	if rctx.methodNotAllowed {
+		if mx.cfg != nil && mx.cfg.autoOptions {
+			rctx.addAllowedMethod(mOPTIONS)
+			if method == mOPTIONS {
+				autoOptionsHandler(w, r)
+				return
+			}
+		}
 		mx.MethodNotAllowedHandler().ServeHTTP(w, r)
 	} else {
 		mx.NotFoundHandler().ServeHTTP(w, r)
 	}
 }
 
+// findRoute looks the route up in the routing tree, applying the path matching options of the mux.
+// Under the TrailingSlashRedirect policy, when the route is only found with the trailing slash of
+// the path toggled, no route is returned but the routing path to redirect to.
+func (mx *Mux) findRoute(rctx *Context, method methodType, path string) (*node, http.Handler, string) {
+	if mx.cfg == nil {
+		rctx.foldCase = false
+		n, _, h := mx.tree.load().FindRoute(rctx, method, path)
+		return n, h, ""
+	}
+
+	rctx.foldCase = mx.cfg.caseInsensitive
+	if mx.cfg.cleanPath {
+		if cp := cleanPath(path); cp != path {
+			if rctx.trace != nil {
+				rctx.trace.add(StepRoutePath, cp, path, true, "clean path")
+			}
+			path = cp
+		}
+	}
+
+	root := mx.tree.load()
+	n, _, h := root.FindRoute(rctx, method, path)
+	if h != nil || rctx.methodNotAllowed || mx.cfg.trailingSlash == TrailingSlashStrict || path == "/" {
+		return n, h, ""
+	}
+
+	var altPath string
+	if path[len(path)-1] == '/' {
+		altPath = path[:len(path)-1]
+	} else if mx.cfg.trailingSlash == TrailingSlashRedirect {
+		altPath = path + "/"
+	} else {
+		return n, h, ""
+	}
+
+	if rctx.trace != nil {
+		rctx.trace.add(StepRoutePath, altPath, path, true, "trailing slash")
+	}
+
+	n, _, h = root.FindRoute(rctx, method, altPath)
+	if h != nil && mx.cfg.trailingSlash == TrailingSlashRedirect {
+		return nil, nil, altPath
+	}
+
+	return n, h, ""
+}
+
 // updateRouteHandler builds a single mux handler, which is a chain of middlewares stack defined by Use() calls,
 // and the tree router (Mux) itself. After this point, no other middleware can be registered in the stack of this Mux.
 // But it is still possible to link additional middlewares through Group() or using the chain of middleware handlers.
@@ -444,9 +793,24 @@ func (mx *Mux) updateRouteHandler() {
 	mx.handler = chain(mx.middlewares, http.HandlerFunc(mx.routeHTTP))
 }
 
+// inheritConfig shares the routing options of the mux with a sub-router which is not configured,
+// and recursively with the sub-routers mounted on it. The sub-router inherits the custom methods of the mux.
+func (mx *Mux) inheritConfig(subMux *Mux) {
+	subMux.owner().methods.inherit(mx.methods)
+
+	if mx.cfg == nil || subMux.cfg != nil {
+		return
+	}
+
+	subMux.cfg = mx.cfg
+	subMux.updateSubRoutes(func(m *Mux) {
+		subMux.inheritConfig(m)
+	})
+}
+
 // Recursively update data on child routers.
 func (mx *Mux) updateSubRoutes(fn func(subMux *Mux)) {
-	for _, r := range mx.tree.routes() {
+	for _, r := range mx.Routes() {
 		subMux, ok := r.SubRoutes.(*Mux)
 		if !ok {
 			continue
@@ -455,8 +819,15 @@ func (mx *Mux) updateSubRoutes(fn func(subMux *Mux)) {
 	}
 }
 
-// methodNotAllowedHandler is a helper function to respond with a 405, method not allowed.
+// methodNotAllowedHandler is a helper function to respond with a 405, method not allowed,
+// listing the methods supported by the route in the Allow header.
 func methodNotAllowedHandler(w http.ResponseWriter, r *http.Request) {
+	if rctx := RouteContext(r.Context()); rctx != nil {
+		if methods := rctx.AllowedMethods(); len(methods) > 0 {
+			w.Header().Set("Allow", strings.Join(methods, ", "))
+		}
+	}
+
 	w.WriteHeader(405)
 	_, err := w.Write(nil)
 	if err != nil {
@@ -8,6 +8,9 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -1339,7 +1342,7 @@ func TestMuxWildcardRoute(t *testing.T) {
 	}()
 
 	r := NewRouter()
-	r.Get("/*/wildcard/must/be/at/end", handler)
+	r.Get("/*wildcard/must/span/whole/segments", handler)
 }
 
 func TestMuxWildcardRouteCheckTwo(t *testing.T) {
@@ -1352,7 +1355,53 @@ func TestMuxWildcardRouteCheckTwo(t *testing.T) {
 	}()
 
 	r := NewRouter()
-	r.Get("/*/wildcard/{must}/be/at/end", handler)
+	r.Get("/{path...}wildcard/{must}/span/whole/segments", handler)
+}
+
+func TestMuxWildcardSegments(t *testing.T) {
+	r := NewRouter()
+	r.Named("blob").Get("/repos/{owner}/{repo}/blob/{ref}/{path...}/raw", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(fmt.Sprintf("%s/%s@%s:%s %s", URLParam(r, "owner"), URLParam(r, "repo"),
+			URLParam(r, "ref"), URLParam(r, "path"), RouteContext(r.Context()).RoutePattern())))
+	})
+	r.Route("/files", func(r Router) {
+		r.Get("/*/metadata", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("meta:" + URLParam(r, "*") + " " + RouteContext(r.Context()).RoutePattern()))
+		})
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		path string
+		body string
+	}{
+		{"/repos/pchchv/gor/blob/v1/docs/a b.md/raw", "pchchv/gor@v1:docs/a b.md /repos/{owner}/{repo}/blob/{ref}/{path...}/raw"},
+		{"/repos/pchchv/gor/blob/v1/raw", "404 page not found\n"},
+		{"/files/a/b/metadata", "meta:a/b /files/*/metadata"},
+		{"/files/metadata", "404 page not found\n"},
+	}
+	for _, tt := range tests {
+		if _, body := testRequest(t, ts, "GET", tt.path, nil); body != tt.body {
+			t.Fatalf("%s: expecting '%s', got '%s'", tt.path, tt.body, body)
+		}
+	}
+
+	var patterns []string
+	Walk(r, func(method, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		patterns = append(patterns, route)
+		return nil
+	})
+	sort.Strings(patterns)
+	if expected := []string{"/files/*/metadata", "/repos/{owner}/{repo}/blob/{ref}/{path...}/raw"}; !reflect.DeepEqual(patterns, expected) {
+		t.Fatalf("expecting walked routes %v, got %v", expected, patterns)
+	}
+
+	u, err := r.URLFor("blob", "owner", "pchchv", "repo", "gor", "ref", "v1", "path", "docs/a b.md")
+	if err != nil || u != "/repos/pchchv/gor/blob/v1/docs/a%20b.md/raw" {
+		t.Fatalf("unexpected URL '%s', %v", u, err)
+	}
 }
 
 func TestMuxRegexp(t *testing.T) {
@@ -1639,6 +1688,403 @@ func TestServerBaseContext(t *testing.T) {
 	}
 }
 
+func TestMuxMethodNotAllowedAllowHeader(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := NewRouter()
+	r.Get("/items", h)
+	r.Post("/items", h)
+	r.Delete("/items/{id}", h)
+	r.Route("/users", func(r Router) {
+		r.Put("/{id}", h)
+		r.Patch("/{id}", h)
+	})
+
+	sr := NewRouter()
+	sr.Get("/", h)
+	sr.MethodNotAllowed(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(405)
+		w.Write([]byte(strings.Join(RouteContext(r.Context()).AllowedMethods(), ",")))
+	})
+	r.Mount("/custom", sr)
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		method string
+		path   string
+		allow  string
+		body   string
+	}{
+		{"PUT", "/items", "GET, POST", ""},
+		{"GET", "/items/1", "DELETE", ""},
+		{"GET", "/users/1", "PATCH, PUT", ""},
+		{"OPTIONS", "/items", "GET, POST", ""},
+		{"POST", "/custom", "", "GET"},
+	}
+
+	for _, tt := range tests {
+		resp, body := testRequest(t, ts, tt.method, tt.path, nil)
+		if resp.StatusCode != 405 {
+			t.Fatalf("%s %s: expecting 405, got %d", tt.method, tt.path, resp.StatusCode)
+		}
+		if allow := resp.Header.Get("Allow"); allow != tt.allow {
+			t.Fatalf("%s %s: expecting Allow '%s', got '%s'", tt.method, tt.path, tt.allow, allow)
+		}
+		if body != tt.body {
+			t.Fatalf("%s %s: expecting body '%s', got '%s'", tt.method, tt.path, tt.body, body)
+		}
+	}
+}
+
+func TestMuxAutoOptions(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := NewRouter(WithAutoOptions())
+	r.Get("/items", h)
+	r.Post("/items", h)
+	r.Options("/explicit", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("explicit"))
+	})
+	r.Route("/users", func(r Router) {
+		r.Get("/{id}", h)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	resp, _ := testRequest(t, ts, "OPTIONS", "/items", nil)
+	if resp.StatusCode != 204 || resp.Header.Get("Allow") != "GET, OPTIONS, POST" {
+		t.Fatalf("expecting 204 with Allow, got %d '%s'", resp.StatusCode, resp.Header.Get("Allow"))
+	}
+
+	resp, _ = testRequest(t, ts, "OPTIONS", "/users/1", nil)
+	if resp.StatusCode != 204 || resp.Header.Get("Allow") != "GET, OPTIONS" {
+		t.Fatalf("expecting 204 with Allow from the sub-router, got %d '%s'", resp.StatusCode, resp.Header.Get("Allow"))
+	}
+
+	resp, _ = testRequest(t, ts, "DELETE", "/items", nil)
+	if resp.StatusCode != 405 || resp.Header.Get("Allow") != "GET, OPTIONS, POST" {
+		t.Fatalf("expecting 405 with Allow, got %d '%s'", resp.StatusCode, resp.Header.Get("Allow"))
+	}
+
+	if _, body := testRequest(t, ts, "OPTIONS", "/explicit", nil); body != "explicit" {
+		t.Fatalf(body)
+	}
+
+	if resp, _ := testRequest(t, ts, "OPTIONS", "/nope", nil); resp.StatusCode != 404 {
+		t.Fatalf("expecting 404, got %d", resp.StatusCode)
+	}
+}
+
+func TestMuxTrailingSlashPolicy(t *testing.T) {
+	routes := func(r Router) {
+		r.Get("/accounts", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("accounts"))
+		})
+		r.Get("/folders/", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("folders"))
+		})
+		r.Route("/users", func(r Router) {
+			r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("users"))
+			})
+			r.Get("/{id}", func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("user:" + URLParam(r, "id")))
+			})
+		})
+	}
+
+	strip := NewRouter(WithTrailingSlash(TrailingSlashStrip))
+	routes(strip)
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"/accounts", 200, "accounts"},
+		{"/accounts/", 200, "accounts"},
+		{"/folders/", 200, "folders"},
+		{"/folders", 404, "404 page not found\n"},
+		{"/users/", 200, "users"},
+		{"/users/5/", 200, "user:5"},
+	}
+	for _, tt := range tests {
+		resp, body := testHandler(t, strip, "GET", tt.path, nil)
+		if resp.StatusCode != tt.status || body != tt.body {
+			t.Fatalf("strip %s: expecting %d '%s', got %d '%s'", tt.path, tt.status, tt.body, resp.StatusCode, body)
+		}
+	}
+
+	redirect := NewRouter(WithTrailingSlash(TrailingSlashRedirect))
+	routes(redirect)
+
+	redirects := []struct {
+		method   string
+		path     string
+		status   int
+		location string
+	}{
+		{"GET", "/accounts", 200, ""},
+		{"GET", "/accounts/?a=1&b=2", 301, "/accounts?a=1&b=2"},
+		{"GET", "/folders", 301, "/folders/"},
+		{"GET", "/users/5/?x=y", 301, "/users/5?x=y"},
+		{"POST", "/users/5/", 405, ""},
+		{"GET", "/nope/", 404, ""},
+	}
+	for _, tt := range redirects {
+		resp, _ := testHandler(t, redirect, tt.method, tt.path, nil)
+		if resp.StatusCode != tt.status || resp.Header.Get("Location") != tt.location {
+			t.Fatalf("redirect %s %s: expecting %d '%s', got %d '%s'", tt.method, tt.path, tt.status, tt.location,
+				resp.StatusCode, resp.Header.Get("Location"))
+		}
+	}
+
+	resp, _ := testHandler(t, redirect, "GET", "//evil.com/", nil)
+	if loc := resp.Header.Get("Location"); strings.HasPrefix(loc, "//") {
+		t.Fatalf("unexpected scheme-relative redirect to '%s'", loc)
+	}
+}
+
+func TestMuxCaseInsensitive(t *testing.T) {
+	r := NewRouter(WithCaseInsensitive())
+	r.Get("/Users/{ID}/Profile", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("profile:" + URLParam(r, "ID")))
+	})
+	r.Route("/api", func(r Router) {
+		r.Get("/Items", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("items"))
+		})
+	})
+
+	tests := []struct {
+		path string
+		body string
+	}{
+		{"/Users/AbC/Profile", "profile:AbC"},
+		{"/users/AbC/profile", "profile:AbC"},
+		{"/USERS/x/PROFILE", "profile:x"},
+		{"/API/items", "items"},
+		{"/api/iTeMs", "items"},
+		{"/api/items2", "404 page not found\n"},
+	}
+	for _, tt := range tests {
+		if _, body := testHandler(t, r, "GET", tt.path, nil); body != tt.body {
+			t.Fatalf("%s: expecting '%s', got '%s'", tt.path, tt.body, body)
+		}
+	}
+
+	strict := NewRouter()
+	strict.Get("/Users", func(w http.ResponseWriter, r *http.Request) {})
+	if resp, _ := testHandler(t, strict, "GET", "/users", nil); resp.StatusCode != 404 {
+		t.Fatalf("expecting case-sensitive routing by default, got %d", resp.StatusCode)
+	}
+}
+
+func TestMuxCleanPath(t *testing.T) {
+	r := NewRouter(WithCleanPath())
+	r.Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("user:" + URLParam(r, "id")))
+	})
+	r.Route("/files", func(r Router) {
+		r.Get("/{name}/", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("dir:" + URLParam(r, "name")))
+		})
+	})
+
+	tests := []struct {
+		path string
+		body string
+	}{
+		{"/users//1", "user:1"},
+		{"/./users/1", "user:1"},
+		{"/users/x/../2", "user:2"},
+		{"/files//docs/", "dir:docs"},
+		{"/files/./docs//", "dir:docs"},
+	}
+	for _, tt := range tests {
+		if _, body := testHandler(t, r, "GET", tt.path, nil); body != tt.body {
+			t.Fatalf("%s: expecting '%s', got '%s'", tt.path, tt.body, body)
+		}
+	}
+
+	for _, p := range []string{"/", "/a/b", "/a/b/"} {
+		if cp := cleanPath(p); cp != p {
+			t.Fatalf("expecting clean path '%s' to be kept, got '%s'", p, cp)
+		}
+	}
+}
+
+func TestMuxOptionalParams(t *testing.T) {
+	r := NewRouter()
+	r.Meta(Meta{"doc": "reports"}).Get("/reports/{year?:int}/{month?}", func(w http.ResponseWriter, r *http.Request) {
+		year, hasYear := URLParamOk(r, "year")
+		month, hasMonth := URLParamOk(r, "month")
+		rctx := RouteContext(r.Context())
+		w.Write([]byte(fmt.Sprintf("%s:%v %s:%v %s %v", year, hasYear, month, hasMonth, rctx.RoutePattern(), rctx.RouteMeta()["doc"])))
+	})
+	r.Get("/pages/{page?}", func(w http.ResponseWriter, r *http.Request) {
+		page, ok := URLParamOk(r, "page")
+		w.Write([]byte(fmt.Sprintf("page %s:%v", page, ok)))
+	})
+	r.Get("/{lang?}", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("lang:" + URLParam(r, "lang")))
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		path string
+		body string
+	}{
+		{"/reports", ":false :false /reports/{year?:int}/{month?} reports"},
+		{"/reports/2024", "2024:true :false /reports/{year?:int}/{month?} reports"},
+		{"/reports/2024/05", "2024:true 05:true /reports/{year?:int}/{month?} reports"},
+		{"/reports/abc", "404 page not found\n"},
+		{"/reports/2024/05/01", "404 page not found\n"},
+		{"/pages", "page :false"},
+		{"/pages/", "404 page not found\n"},
+		{"/pages/2", "page 2:true"},
+		{"/", "lang:"},
+		{"/en", "lang:en"},
+	}
+	for _, tt := range tests {
+		if _, body := testRequest(t, ts, "GET", tt.path, nil); body != tt.body {
+			t.Fatalf("%s: expecting '%s', got '%s'", tt.path, tt.body, body)
+		}
+	}
+
+	var patterns []string
+	Walk(r, func(method, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		patterns = append(patterns, route)
+		return nil
+	})
+	sort.Strings(patterns)
+	if expected := []string{"/pages/{page?}", "/reports/{year?:int}/{month?}", "/{lang?}"}; !reflect.DeepEqual(patterns, expected) {
+		t.Fatalf("expecting walked routes %v, got %v", expected, patterns)
+	}
+
+	for _, pattern := range []string{"/reports/{year?}/{month}", "/reports/{year?}/summary", "/reports/v{year?}", "/reports/{year?}.json"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("expecting a panic for pattern '%s'", pattern)
+				}
+			}()
+			NewRouter().Get(pattern, func(w http.ResponseWriter, r *http.Request) {})
+		}()
+	}
+}
+
+func benchMux() *Mux {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := NewRouter()
+	r.Get("/", h)
+	r.Get("/ping", h)
+	r.Get("/users", h)
+	r.Get("/users/{id}", h)
+	r.Get("/users/{id}/posts", h)
+	r.Route("/api", func(r Router) {
+		r.Get("/items/{id}", h)
+	})
+
+	return r
+}
+
+var benchRoutes = []struct{ name, path string }{
+	{"static", "/ping"},
+	{"param", "/users/42"},
+	{"subrouter-param", "/api/items/42"},
+}
+
+// BenchmarkMuxServeHTTP measures the dispatch of the requests, which does not allocate: the routing context
+// and the request copy are pooled. The param routes allocate the path values of the request on Go 1.22 and later,
+// which http.Request.SetPathValue stores in a map of its own.
+func BenchmarkMuxServeHTTP(b *testing.B) {
+	r := benchMux()
+
+	for _, rt := range benchRoutes {
+		b.Run(rt.name, func(b *testing.B) {
+			req := httptest.NewRequest("GET", rt.path, nil)
+			w := httptest.NewRecorder()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				r.ServeHTTP(w, req)
+			}
+		})
+	}
+}
+
+// BenchmarkMuxMatch measures the route lookup, which does not allocate with a pooled routing context.
+func BenchmarkMuxMatch(b *testing.B) {
+	r := benchMux()
+
+	for _, rt := range benchRoutes {
+		b.Run(rt.name, func(b *testing.B) {
+			rctx := newPooledRouteContext()
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				rctx.Reset()
+				r.Match(rctx, "GET", rt.path)
+			}
+		})
+	}
+}
+
+func TestMuxAllocs(t *testing.T) {
+	r := benchMux()
+	rctx := newPooledRouteContext()
+
+	for _, rt := range benchRoutes {
+		allocs := testing.AllocsPerRun(100, func() {
+			rctx.Reset()
+			if !r.Match(rctx, "GET", rt.path) {
+				t.Fatalf("expecting a route for %s", rt.path)
+			}
+		})
+		if allocs != 0 {
+			t.Fatalf("%s: expecting no allocation for the route lookup, got %v", rt.name, allocs)
+		}
+	}
+
+	if raceEnabled {
+		t.Skip("the pooled routing contexts are dropped at random with the race detector")
+	}
+
+	// the dispatch allocates nothing but the path values of the request for the param routes
+	for _, rt := range benchRoutes {
+		rctx.Reset()
+		r.Match(rctx, "GET", rt.path)
+		want := pathValuesAllocs(rctx)
+
+		req := httptest.NewRequest("GET", rt.path, nil)
+		w := httptest.NewRecorder()
+		if allocs := testing.AllocsPerRun(100, func() { r.ServeHTTP(w, req) }); allocs != want {
+			t.Fatalf("%s: expecting %v allocations for the dispatch, got %v", rt.name, want, allocs)
+		}
+	}
+}
+
+// pathValuesAllocs returns the allocations made by setting the URL params of `rctx` as the path values of a request,
+// which are none before Go 1.22.
+func pathValuesAllocs(rctx *Context) float64 {
+	r, req := httptest.NewRequest("GET", "/", nil), new(http.Request)
+	return testing.AllocsPerRun(100, func() {
+		*req = *r
+		setPathValues(req, rctx)
+	})
+}
+
 func testRequest(t *testing.T, ts *httptest.Server, method, path string, body io.Reader) (*http.Response, string) {
 	req, err := http.NewRequest(method, ts.URL+path, body)
 	if err != nil {
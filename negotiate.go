@@ -0,0 +1,216 @@
+package gor
+
+import (
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Negotiate is a handler serving the representations of a resource, keyed by media type, e.g.
+//
+//	r.Method("GET", "/report", gor.Negotiate{"application/json": jsonH, "text/csv": csvH})
+//
+// The representation is selected by the extension of the request path recorded by the URLFormat middleware
+// if any, or else by the Accept header of the request, see NegotiateContentType. The media types the client
+// accepts equally are served in the order of their names. The Content-Type of the response defaults
+// to the selected media type, and its Vary header lists Accept.
+// A request accepting none of the representations gets a 406 response listing the available media types.
+type Negotiate map[string]http.Handler
+
+func (n Negotiate) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Vary", "Accept")
+
+	offers := make([]string, 0, len(n))
+	for mediaType := range n {
+		offers = append(offers, mediaType)
+	}
+	sort.Strings(offers)
+
+	var mediaType string
+	if rctx := RouteContext(r.Context()); rctx != nil && rctx.URLFormat != "" {
+		mediaType = negotiateFormat(rctx.URLFormat, offers)
+	} else {
+		mediaType = NegotiateContentType(r, offers...)
+	}
+
+	if mediaType == "" {
+		http.Error(w, http.StatusText(http.StatusNotAcceptable)+", available representations: "+strings.Join(offers, ", "), http.StatusNotAcceptable)
+		return
+	}
+
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", mediaType)
+	}
+	n[mediaType].ServeHTTP(w, r)
+}
+
+// formatTypes are the media types of the common URL formats, which the mime package only knows
+// when the system has a table of the extensions, e.g. /etc/mime.types for csv.
+var formatTypes = map[string]string{
+	"csv":  "text/csv",
+	"htm":  "text/html",
+	"html": "text/html",
+	"json": "application/json",
+	"md":   "text/markdown",
+	"pdf":  "application/pdf",
+	"txt":  "text/plain",
+	"xml":  "application/xml",
+	"yaml": "application/yaml",
+	"yml":  "application/yaml",
+}
+
+// negotiateFormat returns the offered media type of the URL format, or an empty string if none is offered.
+// The media type of the format is looked up in formatTypes, or else with mime.TypeByExtension.
+func negotiateFormat(format string, offers []string) string {
+	formatType, ok := formatTypes[strings.ToLower(format)]
+	if !ok {
+		formatType = baseMediaType(mime.TypeByExtension("." + format))
+	}
+	if formatType == "" {
+		return ""
+	}
+
+	for _, offer := range offers {
+		if baseMediaType(offer) == formatType {
+			return offer
+		}
+	}
+	return ""
+}
+
+// AcceptSpec is a range of values accepted by a client, along with its quality value,
+// parsed from an Accept, Accept-Charset, Accept-Encoding or Accept-Language header.
+type AcceptSpec struct {
+	// Value is the lowercase accepted value or range of values, without its parameters,
+	// e.g. "text/html", "text/*", "*/*", "utf-8", "en-us" or "*".
+	Value string
+
+	// Q is the quality value of the range, between 0 and 1.
+	Q float64
+}
+
+// ParseAccept parses the ranges of an Accept* header in the header order,
+// skipping the malformed ones and those with an invalid quality value.
+func ParseAccept(header string) []AcceptSpec {
+	var specs []AcceptSpec
+
+	for _, s := range strings.Split(header, ",") {
+		value, params, _ := strings.Cut(s, ";")
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value == "" {
+			continue
+		}
+
+		spec := AcceptSpec{Value: value, Q: 1}
+		valid := true
+		for _, param := range strings.Split(params, ";") {
+			k, v, _ := strings.Cut(param, "=")
+			if strings.TrimSpace(k) != "q" {
+				continue
+			}
+
+			q, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil || q < 0 || q > 1 {
+				valid = false
+				break
+			}
+			spec.Q = q
+		}
+
+		if valid {
+			specs = append(specs, spec)
+		}
+	}
+
+	return specs
+}
+
+// NegotiateContentType returns the offered media type the client prefers according to the Accept header
+// of the request, or an empty string if it accepts none of them. The quality value of an offer is the one
+// of the most specific range matching it, e.g. "text/csv" then "text/*" then "*/*". Offers of the same quality
+// are preferred in order, and the first offer is returned when the request has no Accept header.
+func NegotiateContentType(r *http.Request, offers ...string) string {
+	return negotiate(r.Header.Get("Accept"), offers, func(spec, offer string) int {
+		offer = baseMediaType(offer)
+		switch {
+		case spec == offer:
+			return 2
+		case spec == "*/*":
+			return 0
+		case strings.HasSuffix(spec, "/*") && strings.HasPrefix(offer, spec[:len(spec)-1]):
+			return 1
+		}
+		return -1
+	})
+}
+
+// NegotiateCharset returns the offered charset the client prefers according to the Accept-Charset header
+// of the request, or an empty string if it accepts none of them, like NegotiateContentType.
+func NegotiateCharset(r *http.Request, offers ...string) string {
+	return negotiate(r.Header.Get("Accept-Charset"), offers, func(spec, offer string) int {
+		switch {
+		case spec == strings.ToLower(offer):
+			return 1
+		case spec == "*":
+			return 0
+		}
+		return -1
+	})
+}
+
+// NegotiateLanguage returns the offered language tag the client prefers according to the Accept-Language header
+// of the request, or an empty string if it accepts none of them, like NegotiateContentType.
+// A language range matches the tags it is a prefix of, e.g. "en" matches "en-US".
+func NegotiateLanguage(r *http.Request, offers ...string) string {
+	return negotiate(r.Header.Get("Accept-Language"), offers, func(spec, offer string) int {
+		offer = strings.ToLower(offer)
+		switch {
+		case spec == offer || strings.HasPrefix(offer, spec+"-"):
+			return len(spec)
+		case spec == "*":
+			return 0
+		}
+		return -1
+	})
+}
+
+// negotiate returns the offer of the highest quality value according to the Accept* header,
+// the quality value of an offer being the one of the range for which match returns the highest specificity.
+// match returns a negative specificity for the ranges not matching the offer.
+func negotiate(header string, offers []string, match func(spec, offer string) int) string {
+	if len(offers) == 0 {
+		return ""
+	}
+
+	specs := ParseAccept(header)
+	if len(specs) == 0 {
+		if strings.TrimSpace(header) == "" {
+			return offers[0]
+		}
+		return ""
+	}
+
+	best, bestQ := "", 0.0
+	for _, offer := range offers {
+		q, specificity := 0.0, -1
+		for _, spec := range specs {
+			if s := match(spec.Value, offer); s > specificity {
+				q, specificity = spec.Q, s
+			}
+		}
+
+		if q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+
+	return best
+}
+
+// baseMediaType returns the lowercase media type without its parameters.
+func baseMediaType(mediaType string) string {
+	mediaType, _, _ = strings.Cut(mediaType, ";")
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
@@ -0,0 +1,113 @@
+package gor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestParseAccept(t *testing.T) {
+	specs := ParseAccept("text/html, application/xhtml+xml;level=1, application/xml;q=0.9, */*;q=0.8, bad;q=2, ,image/*;q=0")
+	expected := []AcceptSpec{
+		{"text/html", 1}, {"application/xhtml+xml", 1}, {"application/xml", 0.9}, {"*/*", 0.8}, {"image/*", 0},
+	}
+	if !reflect.DeepEqual(specs, expected) {
+		t.Fatalf("expecting %v, got %v", expected, specs)
+	}
+}
+
+func TestNegotiateHeaders(t *testing.T) {
+	tests := []struct {
+		header string
+		value  string
+		fn     func(r *http.Request, offers ...string) string
+		offers []string
+		want   string
+	}{
+		{"Accept", "", NegotiateContentType, []string{"application/json", "text/csv"}, "application/json"},
+		{"Accept", "text/csv", NegotiateContentType, []string{"application/json", "text/csv"}, "text/csv"},
+		{"Accept", "text/*;q=0.5, application/json;q=0.4", NegotiateContentType, []string{"application/json", "text/csv"}, "text/csv"},
+		{"Accept", "*/*;q=0.1, application/json", NegotiateContentType, []string{"text/csv", "application/json; charset=utf-8"}, "application/json; charset=utf-8"},
+		{"Accept", "text/*, text/csv;q=0", NegotiateContentType, []string{"text/csv"}, ""},
+		{"Accept", "image/png", NegotiateContentType, []string{"application/json"}, ""},
+		{"Accept-Charset", "iso-8859-1;q=0.5, *;q=0.1", NegotiateCharset, []string{"UTF-8", "ISO-8859-1"}, "ISO-8859-1"},
+		{"Accept-Charset", "ascii", NegotiateCharset, []string{"utf-8"}, ""},
+		{"Accept-Language", "fr-CH, fr;q=0.9, en;q=0.8, *;q=0.5", NegotiateLanguage, []string{"en-US", "fr-FR", "de"}, "fr-FR"},
+		{"Accept-Language", "de-AT, en;q=0.8", NegotiateLanguage, []string{"de", "en-GB"}, "en-GB"},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest("GET", "/", nil)
+		if tt.value != "" {
+			r.Header.Set(tt.header, tt.value)
+		}
+		if got := tt.fn(r, tt.offers...); got != tt.want {
+			t.Fatalf("%s: %s: expecting %q, got %q", tt.header, tt.value, tt.want, got)
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	r := NewRouter()
+	r.Method("GET", "/report", Negotiate{
+		"application/json": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"report":1}`))
+		}),
+		"text/csv": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+			w.Write([]byte("report\n1\n"))
+		}),
+	})
+
+	tests := []struct {
+		accept      string
+		status      int
+		contentType string
+		body        string
+	}{
+		{"", 200, "application/json", `{"report":1}`},
+		{"text/csv", 200, "text/csv; charset=utf-8", "report\n1\n"},
+		{"text/*, application/json;q=0.5", 200, "text/csv; charset=utf-8", "report\n1\n"},
+		{"*/*", 200, "application/json", `{"report":1}`},
+		{"application/xml", 406, "text/plain; charset=utf-8", "Not Acceptable, available representations: application/json, text/csv\n"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", "/report", nil)
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != tt.status || w.Header().Get("Content-Type") != tt.contentType || w.Body.String() != tt.body {
+			t.Fatalf("%s: expecting %d %q %q, got %d %q %q", tt.accept, tt.status, tt.contentType, tt.body, w.Code, w.Header().Get("Content-Type"), w.Body.String())
+		}
+		if w.Header().Get("Vary") != "Accept" {
+			t.Fatalf("%s: expecting a Vary header, got %q", tt.accept, w.Header().Get("Vary"))
+		}
+	}
+}
+
+func TestNegotiateFormat(t *testing.T) {
+	offers := []string{"application/json", "application/yaml", "text/csv; charset=utf-8"}
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"csv", "text/csv; charset=utf-8"},
+		{"CSV", "text/csv; charset=utf-8"},
+		{"json", "application/json"},
+		{"yml", "application/yaml"},
+		{"xml", ""},
+		{"nope", ""},
+	}
+
+	for _, tt := range tests {
+		if got := negotiateFormat(tt.format, offers); got != tt.want {
+			t.Errorf("negotiateFormat(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
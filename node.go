@@ -5,7 +5,6 @@ import (
 	"net/http"
 	"regexp"
 	"sort"
-	"strconv"
 	"strings"
 )
 
@@ -16,9 +15,17 @@ type node struct {
 	// regexp matcher for regexp nodes
 	rex *regexp.Regexp
 
+	// typed matcher for regexp nodes using a registered param type
+	match func(string) bool
+
 	// HTTP handler endpoints on the leaf node
 	endpoints endpoints
 
+	// index of the leaf nodes of the fully static routes by path, on the root node,
+	// looked up before the traversal of the tree. It is only rebuilt on a copy of the tree
+	// in the WithDynamicRoutes mode, and changed in place otherwise.
+	static map[string]*node
+
 	// prefix is the common prefix we ignore
 	prefix string
 
@@ -44,13 +51,21 @@ type endpoint struct {
 
 	// parameter keys recorded on handler nodes
 	paramKeys []string
+
+	// route metadata attached at registration time
+	meta Meta
+
+	// alias flags the routes expanded from a pattern without some of its optional params
+	alias bool
 }
 
 // Route describes the details of a routing handler.
 type Route struct {
 	SubRoutes Routes
 	Handlers  map[string]http.Handler // HTTP method
+	Meta      map[string]Meta         // HTTP method
 	Pattern   string
+	Host      string // host pattern of host routers
 }
 
 // endpoints is a mapping of http method constants to handlers for a given route.
@@ -69,10 +84,18 @@ const (
 	ntStatic   nodeType = iota // /home
 	ntRegexp                   // /{id:[0-9]+}
 	ntParam                    // /{user}
-	ntCatchAll                 // /api/v1/*
+	ntCatchAll                 // /api/v1/*, /files/*/raw, /files/{path...}/raw
+)
 
+// The method types of the endpoints are the numbers of the methods, above the flags of the endpoints
+// of the mounts and of the routes of all of the methods, so that any number of custom methods can be registered.
+const (
 	mSTUB methodType = 1 << iota
-	mCONNECT
+	mALL
+)
+
+const (
+	mCONNECT methodType = (iota + 1) << 2
 	mDELETE
 	mGET
 	mHEAD
@@ -83,31 +106,51 @@ const (
 	mTRACE
 )
 
-var (
-	mALL = mCONNECT | mDELETE | mGET | mHEAD |
-		mOPTIONS | mPATCH | mPOST | mPUT | mTRACE
-
-	methodMap = map[string]methodType{
-		http.MethodConnect: mCONNECT,
-		http.MethodDelete:  mDELETE,
-		http.MethodGet:     mGET,
-		http.MethodHead:    mHEAD,
-		http.MethodOptions: mOPTIONS,
-		http.MethodPatch:   mPATCH,
-		http.MethodPost:    mPOST,
-		http.MethodPut:     mPUT,
-		http.MethodTrace:   mTRACE,
+// covers reports whether the endpoints set by the method type, e.g. mALL|mSTUB, include the endpoint of `mt`.
+func (method methodType) covers(mt methodType) bool {
+	switch {
+	case mt == mSTUB:
+		return method&mSTUB != 0
+	case method&mALL != 0:
+		return true
 	}
-)
+	return method == mt
+}
+
+// methodMap maps the standard methods to their method types, the custom methods being registered with
+// RegisterMethod or Mux.RegisterMethod.
+var methodMap = map[string]methodType{
+	http.MethodConnect: mCONNECT,
+	http.MethodDelete:  mDELETE,
+	http.MethodGet:     mGET,
+	http.MethodHead:    mHEAD,
+	http.MethodOptions: mOPTIONS,
+	http.MethodPatch:   mPATCH,
+	http.MethodPost:    mPOST,
+	http.MethodPut:     mPUT,
+	http.MethodTrace:   mTRACE,
+}
 
 func (n *node) FindRoute(rctx *Context, method methodType, path string) (*node, endpoints, http.Handler) {
-	// reset context routing pattern and params
+	// reset context routing pattern, params and method not allowed hints
 	rctx.routePattern = ""
 	rctx.routeParams.Keys = rctx.routeParams.Keys[:0]
 	rctx.routeParams.Values = rctx.routeParams.Values[:0]
-
-	// find routing handlers for the path
-	rn := n.findRoute(rctx, method, path)
+	rctx.methodNotAllowed = false
+	rctx.methodsAllowed = rctx.methodsAllowed[:0]
+
+	// find routing handlers for the path, in the static routes index first.
+	// The index only serves the paths whose static route has a handler for the method,
+	// so that the traversal records the allowed methods and the routes of the other node types.
+	var rn *node
+	if sn := n.static[path]; sn != nil && rctx.trace == nil {
+		if h := sn.endpoints.get(method); h != nil && h.handler != nil {
+			rn = sn
+		}
+	}
+	if rn == nil {
+		rn = n.findRoute(rctx, method, path)
+	}
 	if rn == nil {
 		return nil, nil, nil
 	}
@@ -116,13 +159,20 @@ func (n *node) FindRoute(rctx *Context, method methodType, path string) (*node,
 	rctx.URLParams.Keys = append(rctx.URLParams.Keys, rctx.routeParams.Keys...)
 	rctx.URLParams.Values = append(rctx.URLParams.Values, rctx.routeParams.Values...)
 
+	ep := rn.endpoints.get(method)
+
 	// record routing pattern in the request lifecycle
-	if rn.endpoints[method].pattern != "" {
-		rctx.routePattern = rn.endpoints[method].pattern
+	if ep.pattern != "" {
+		rctx.routePattern = ep.pattern
 		rctx.RoutePatterns = append(rctx.RoutePatterns, rctx.routePattern)
 	}
 
-	return rn, rn.endpoints, rn.endpoints[method].handler
+	// record route metadata in the request lifecycle
+	if ep.meta != nil {
+		rctx.routeMetas = append(rctx.routeMetas, ep.meta)
+	}
+
+	return rn, rn.endpoints, ep.handler
 }
 
 // Recursive traversal of edges, checking all nodeType groups along the path.
@@ -149,7 +199,17 @@ func (n *node) findRoute(rctx *Context, method methodType, path string) *node {
 		case ntStatic:
 			xn = nds.findEdge(label)
 			if xn == nil || !strings.HasPrefix(xsearch, xn.prefix) {
-				continue
+				if rctx.foldCase {
+					xn = nds.findEdgeFold(xsearch)
+				} else if xn != nil && rctx.trace != nil {
+					rctx.trace.node(xn, search, false, "prefix mismatch")
+				}
+				if xn == nil || !rctx.foldCase {
+					continue
+				}
+			}
+			if rctx.trace != nil {
+				rctx.trace.node(xn, search, true, "")
 			}
 			xsearch = xsearch[len(xn.prefix):]
 
@@ -170,36 +230,58 @@ func (n *node) findRoute(rctx *Context, method methodType, path string) *node {
 					if xn.tail == '/' {
 						p = len(xsearch)
 					} else {
+						if rctx.trace != nil {
+							rctx.trace.node(xn, search, false, fmt.Sprintf("no '%c' delimiter", xn.tail))
+						}
 						continue
 					}
 				} else if ntyp == ntRegexp && p == 0 {
+					if rctx.trace != nil {
+						rctx.trace.node(xn, search, false, "empty value")
+					}
 					continue
 				}
 
-				if ntyp == ntRegexp && xn.rex != nil {
-					if !xn.rex.MatchString(xsearch[:p]) {
+				if ntyp == ntRegexp && (xn.rex != nil || xn.match != nil) {
+					if !xn.matchParam(xsearch[:p]) {
+						if rctx.trace != nil {
+							rctx.trace.node(xn, search, false, fmt.Sprintf("value '%s' does not match", xsearch[:p]))
+						}
 						continue
 					}
 				} else if strings.IndexByte(xsearch[:p], '/') != -1 {
 					// avoid a match across path segments
+					if rctx.trace != nil {
+						rctx.trace.node(xn, search, false, fmt.Sprintf("value '%s' spans path segments", xsearch[:p]))
+					}
 					continue
 				}
 
+				if rctx.trace != nil {
+					rctx.trace.node(xn, search, true, fmt.Sprintf("value '%s'", xsearch[:p]))
+				}
+
 				prevlen := len(rctx.routeParams.Values)
 				rctx.routeParams.Values = append(rctx.routeParams.Values, xsearch[:p])
 				xsearch = xsearch[p:]
 
 				if len(xsearch) == 0 {
 					if xn.isLeaf() {
-						h := xn.endpoints[method]
+						h := xn.endpoints.get(method)
 						if h != nil && h.handler != nil {
 							rctx.routeParams.Keys = append(rctx.routeParams.Keys, h.paramKeys...)
+							if rctx.trace != nil {
+								rctx.trace.endpoint(xn, method, true)
+							}
 							return xn
 						}
 
 						// flag that the routing context found a route,
 						// but not a corresponding supported method
-						rctx.methodNotAllowed = true
+						xn.setMethodNotAllowed(rctx)
+						if rctx.trace != nil {
+							rctx.trace.endpoint(xn, method, false)
+						}
 					}
 				}
 
@@ -218,8 +300,33 @@ func (n *node) findRoute(rctx *Context, method methodType, path string) *node {
 
 		default:
 			// catch-all nodes
-			rctx.routeParams.Values = append(rctx.routeParams.Values, search)
 			xn = nds[0]
+
+			// wildcards followed by more segments capture the longest path first,
+			// backtracking segment by segment to shorter captures
+			if xn.hasChildren() {
+				for p := strings.LastIndexByte(search, '/'); p > 0; p = strings.LastIndexByte(search[:p], '/') {
+					if rctx.trace != nil {
+						rctx.trace.node(xn, search, true, fmt.Sprintf("value '%s'", search[:p]))
+					}
+
+					prevlen := len(rctx.routeParams.Values)
+					rctx.routeParams.Values = append(rctx.routeParams.Values, search[:p])
+
+					fin := xn.findRoute(rctx, method, search[p:])
+					if fin != nil {
+						return fin
+					}
+
+					rctx.routeParams.Values = rctx.routeParams.Values[:prevlen]
+				}
+			}
+
+			// trailing wildcards capture the rest of the path
+			if rctx.trace != nil {
+				rctx.trace.node(xn, search, true, fmt.Sprintf("value '%s'", search))
+			}
+			rctx.routeParams.Values = append(rctx.routeParams.Values, search)
 			xsearch = ""
 		}
 
@@ -230,15 +337,21 @@ func (n *node) findRoute(rctx *Context, method methodType, path string) *node {
 		// did we find it yet?
 		if len(xsearch) == 0 {
 			if xn.isLeaf() {
-				h := xn.endpoints[method]
+				h := xn.endpoints.get(method)
 				if h != nil && h.handler != nil {
 					rctx.routeParams.Keys = append(rctx.routeParams.Keys, h.paramKeys...)
+					if rctx.trace != nil {
+						rctx.trace.endpoint(xn, method, true)
+					}
 					return xn
 				}
 
 				// flag that the routing context found a route,
 				// but not a corresponding supported method
-				rctx.methodNotAllowed = true
+				xn.setMethodNotAllowed(rctx)
+				if rctx.trace != nil {
+					rctx.trace.endpoint(xn, method, false)
+				}
 			}
 		}
 
@@ -260,9 +373,72 @@ func (n *node) findRoute(rctx *Context, method methodType, path string) *node {
 	return nil
 }
 
+// InsertRoute adds the handler of a routing pattern to the tree and returns its leaf node.
+// Patterns ending with optional params, e.g. "/reports/{year?}/{month?}", are expanded into a route
+// for each number of present params, the returned node being the one of the route with all of the params.
 func (n *node) InsertRoute(method methodType, pattern string, handler http.Handler) *node {
+	if err := patValidate(pattern); err != nil {
+		panic(err)
+	}
+
+	nodes := n.insertRoutes(method, pattern, handler)
+	return nodes[len(nodes)-1]
+}
+
+// insertRoutes adds the routes expanded from the optional params of a valid routing pattern to the tree,
+// returning their leaf nodes from the shortest route to the longest one.
+// The endpoints of all of the routes record the pattern as is, the shorter routes being flagged as aliases.
+func (n *node) insertRoutes(method methodType, pattern string, handler http.Handler) []*node {
+	routes := patExpandOptional(pattern)
+	nodes := make([]*node, len(routes))
+	for i, route := range routes {
+		nodes[i] = n.insertRoute(method, pattern, route, handler)
+		if isStaticRoute(route) {
+			n.indexStatic(route, nodes[i])
+		}
+		if i < len(routes)-1 {
+			nodes[i].eachEndpoint(method, func(h *endpoint) {
+				h.alias = true
+			})
+		}
+	}
+
+	return nodes
+}
+
+// indexStatic adds the leaf node of a fully static route to the static routes index of the root node.
+func (n *node) indexStatic(route string, leaf *node) {
+	if n.static == nil {
+		n.static = make(map[string]*node)
+	}
+	n.static[route] = leaf
+}
+
+// reindexStatic rebuilds the static routes index of the root node from the tree.
+func (n *node) reindexStatic() {
+	n.static = nil
+	n.indexStaticChildren(n, "")
+}
+
+func (n *node) indexStaticChildren(root *node, path string) {
+	for _, c := range n.child[ntStatic] {
+		route := path + c.prefix
+		if c.endpoints != nil {
+			root.indexStatic(route, c)
+		}
+		c.indexStaticChildren(root, route)
+	}
+}
+
+// isStaticRoute reports whether a route has no param, regexp or wildcard segments.
+func isStaticRoute(route string) bool {
+	return strings.IndexByte(route, '{') < 0 && strings.IndexByte(route, '*') < 0
+}
+
+func (n *node) insertRoute(method methodType, pattern, route string, handler http.Handler) *node {
 	var parent *node
-	search := pattern
+	search := route
+	paramKeys := patParamKeys(route)
 
 	for {
 		var segTail byte
@@ -274,7 +450,7 @@ func (n *node) InsertRoute(method methodType, pattern string, handler http.Handl
 		// handle key exhaustion
 		if len(search) == 0 {
 			// insert or update the node's leaf handler
-			n.setEndpoint(method, handler, pattern)
+			n.setEndpoint(method, handler, pattern, paramKeys)
 			return n
 		}
 
@@ -282,6 +458,10 @@ func (n *node) InsertRoute(method methodType, pattern string, handler http.Handl
 		label := search[0]
 		if label == '{' || label == '*' {
 			segType, _, segRexpat, segTail, _, segEndIdx = patNextSegment(search)
+			if segType == ntCatchAll {
+				// named and unnamed wildcards share the same node
+				label = '*'
+			}
 		}
 
 		if segType == ntRegexp {
@@ -296,7 +476,7 @@ func (n *node) InsertRoute(method methodType, pattern string, handler http.Handl
 		if n == nil {
 			child := &node{label: label, tail: segTail, prefix: search}
 			hn := parent.addChild(child, search)
-			hn.setEndpoint(method, handler, pattern)
+			hn.setEndpoint(method, handler, pattern, paramKeys)
 
 			return hn
 		}
@@ -336,7 +516,7 @@ func (n *node) InsertRoute(method methodType, pattern string, handler http.Handl
 		// if the new key is a subset, set the method/handler on this node and finish.
 		search = search[commonPrefix:]
 		if len(search) == 0 {
-			child.setEndpoint(method, handler, pattern)
+			child.setEndpoint(method, handler, pattern, paramKeys)
 			return child
 		}
 
@@ -347,7 +527,7 @@ func (n *node) InsertRoute(method methodType, pattern string, handler http.Handl
 			prefix: search,
 		}
 		hn := child.addChild(subchild, search)
-		hn.setEndpoint(method, handler, pattern)
+		hn.setEndpoint(method, handler, pattern, paramKeys)
 
 		return hn
 	}
@@ -379,7 +559,11 @@ func (n *node) findPattern(pattern string) bool {
 			idx = strings.IndexByte(pattern, '}') + 1
 
 		case ntCatchAll:
-			idx = longestPrefix(pattern, "*")
+			if pattern[0] == '{' {
+				idx = strings.IndexByte(pattern, '}') + 1
+			} else {
+				idx = longestPrefix(pattern, "*")
+			}
 
 		default:
 			panic("gor: unknown node type")
@@ -435,10 +619,54 @@ func (n *node) replaceChild(label, tail byte, child *node) {
 	panic("gor: replacing missing child")
 }
 
+// setMethodNotAllowed flags the routing context with a route found without a handler for the method,
+// recording the methods the route supports.
+func (n *node) setMethodNotAllowed(rctx *Context) {
+	rctx.methodNotAllowed = true
+	for mt, h := range n.endpoints {
+		if mt == mSTUB || mt == mALL || h.handler == nil {
+			continue
+		}
+		rctx.addAllowedMethod(mt)
+	}
+}
+
+// matchParam reports whether the param value is accepted by the node's typed matcher or regexp.
+func (n *node) matchParam(value string) bool {
+	if n.match != nil {
+		return n.match(value)
+	}
+	return n.rex.MatchString(value)
+}
+
 func (n *node) isLeaf() bool {
 	return n.endpoints != nil
 }
 
+func (n *node) hasChildren() bool {
+	for _, nds := range n.child {
+		if len(nds) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// segment describes the part of the routing patterns matched by the node:
+// the static prefix, "{:regexp}", "{}" for a param or "*" for a wildcard.
+func (n *node) segment() string {
+	switch n.ntype {
+	case ntStatic:
+		return n.prefix
+	case ntRegexp:
+		return "{:" + n.prefix + "}"
+	case ntParam:
+		return "{}"
+	default:
+		return "*"
+	}
+}
+
 func (n *node) getEdge(ntyp nodeType, label, tail byte, prefix string) *node {
 	nds := n.child[ntyp]
 
@@ -481,7 +709,7 @@ func (n *node) routes() []Route {
 		pats := make(map[string]endpoints)
 
 		for mt, h := range eps {
-			if h.pattern == "" {
+			if h.pattern == "" || h.alias {
 				continue
 			}
 			p, ok := pats[h.pattern]
@@ -494,8 +722,12 @@ func (n *node) routes() []Route {
 
 		for p, mh := range pats {
 			hs := make(map[string]http.Handler)
+			var ms map[string]Meta
 			if mh[mALL] != nil && mh[mALL].handler != nil {
 				hs["*"] = mh[mALL].handler
+				if mh[mALL].meta != nil {
+					ms = map[string]Meta{"*": mh[mALL].meta}
+				}
 			}
 
 			for mt, h := range mh {
@@ -507,9 +739,15 @@ func (n *node) routes() []Route {
 					continue
 				}
 				hs[m] = h.handler
+				if h.meta != nil {
+					if ms == nil {
+						ms = make(map[string]Meta)
+					}
+					ms[m] = h.meta
+				}
 			}
 
-			rt := Route{subroutes, hs, p}
+			rt := Route{SubRoutes: subroutes, Handlers: hs, Meta: ms, Pattern: p}
 			rts = append(rts, rt)
 		}
 
@@ -519,33 +757,56 @@ func (n *node) routes() []Route {
 	return rts
 }
 
-func (n *node) setEndpoint(method methodType, handler http.Handler, pattern string) {
+func (n *node) setEndpoint(method methodType, handler http.Handler, pattern string, paramKeys []string) {
 	// set the handler for the method type on the node
 	if n.endpoints == nil {
 		n.endpoints = make(endpoints)
 	}
 
-	paramKeys := patParamKeys(pattern)
-
 	if method&mSTUB == mSTUB {
 		n.endpoints.Value(mSTUB).handler = handler
 	}
-	if method&mALL == mALL {
-		h := n.endpoints.Value(mALL)
-		h.handler = handler
+	n.eachEndpoint(method, func(h *endpoint) {
+		cv, _ := h.handler.(*variantHandler)
+		switch vh, ok := handler.(*variantHandler); {
+		case ok:
+			// the routes with predicates add up, keeping the route without predicates and its metadata
+			h.handler = mergeVariants(h.handler, h.meta, vh)
+		case cv != nil:
+			// the route without predicates replaces the fallback of the routes with predicates
+			h.handler = &variantHandler{variants: cv.variants, fallback: routeVariant{handler: handler}}
+		default:
+			h.handler = handler
+		}
 		h.pattern = pattern
 		h.paramKeys = paramKeys
+		h.meta = nil
+		h.alias = false
+	})
+}
+
+// setEndpointMeta attaches the metadata of the route without predicates to the node endpoints of the method type.
+// The metadata of the fallback of the routes with predicates is kept along with it.
+func (n *node) setEndpointMeta(method methodType, meta Meta) {
+	n.eachEndpoint(method, func(h *endpoint) {
+		if vh, ok := h.handler.(*variantHandler); ok {
+			h.handler = &variantHandler{variants: vh.variants, fallback: routeVariant{handler: vh.fallback.handler, meta: meta}}
+			return
+		}
+		h.meta = meta
+	})
+}
+
+// eachEndpoint calls fn for each endpoint of the node set by the method type,
+// expanding mALL to the standard methods.
+func (n *node) eachEndpoint(method methodType, fn func(h *endpoint)) {
+	if method&mALL == mALL {
+		fn(n.endpoints.Value(mALL))
 		for _, m := range methodMap {
-			h := n.endpoints.Value(m)
-			h.handler = handler
-			h.pattern = pattern
-			h.paramKeys = paramKeys
+			fn(n.endpoints.Value(m))
 		}
 	} else {
-		h := n.endpoints.Value(method)
-		h.handler = handler
-		h.pattern = pattern
-		h.paramKeys = paramKeys
+		fn(n.endpoints.Value(method))
 	}
 }
 
@@ -570,26 +831,22 @@ func (n *node) addChild(child *node, prefix string) *node {
 	default:
 		// search prefix contains a param, regexp or wildcard
 		if segType == ntRegexp {
-			rex, err := regexp.Compile(segRexpat)
-			if err != nil {
-				panic(fmt.Sprintf("gor: invalid regexp pattern '%s' in route param", segRexpat))
+			if match, ok := lookupParamType(segRexpat); ok {
+				child.match = match
+			} else {
+				rex, err := regexp.Compile(segRexpat)
+				if err != nil {
+					panic(fmt.Sprintf("gor: invalid regexp pattern '%s' in route param", segRexpat))
+				}
+				child.rex = rex
 			}
 			child.prefix = segRexpat
-			child.rex = rex
 		}
 
 		if segStartIdx == 0 {
 			// route starts with a param
 			child.ntype = segType
-
-			if segType == ntCatchAll {
-				segStartIdx = -1
-			} else {
-				segStartIdx = segEndIdx
-			}
-			if segStartIdx < 0 {
-				segStartIdx = len(search)
-			}
+			segStartIdx = segEndIdx
 			child.tail = segTail // for params, we set the tail
 
 			if segStartIdx != len(search) {
@@ -613,13 +870,19 @@ func (n *node) addChild(child *node, prefix string) *node {
 			child.ntype = ntStatic
 			child.prefix = search[:segStartIdx]
 			child.rex = nil
+			child.match = nil
 
 			// add the param edge node
 			search = search[segStartIdx:]
 
+			label := search[0]
+			if segType == ntCatchAll {
+				label = '*'
+			}
+
 			nn := &node{
 				ntype: segType,
-				label: search[0],
+				label: label,
 				tail:  segTail,
 			}
 			hn = child.addChild(nn, search)
@@ -661,6 +924,26 @@ func (ns nodes) findEdge(label byte) *node {
 	return ns[idx]
 }
 
+// findEdgeFold returns the static node prefixing the search path, ignoring the ASCII case.
+func (ns nodes) findEdgeFold(search string) *node {
+	if search == "" {
+		return nil
+	}
+
+	c := search[0]
+	for _, label := range [2]byte{c | 0x20, c &^ 0x20} {
+		if label != c && (label|0x20 < 'a' || label|0x20 > 'z') {
+			continue
+		}
+		xn := ns.findEdge(label)
+		if xn != nil && len(search) >= len(xn.prefix) && strings.EqualFold(search[:len(xn.prefix)], xn.prefix) {
+			return xn
+		}
+	}
+
+	return nil
+}
+
 // tailSort pushes nodes with '/' as the tail to the end of the list for param nodes.
 // The list order determines the traversal order.
 func (ns nodes) tailSort() {
@@ -681,19 +964,69 @@ func (s endpoints) Value(method methodType) *endpoint {
 	return mh
 }
 
+// get returns the endpoint of the method type. The routes of all of the methods are expanded to the endpoints
+// of the standard methods when registered, and serve the custom methods without an endpoint of their own.
+func (s endpoints) get(method methodType) *endpoint {
+	if h := s[method]; h != nil || method <= mTRACE {
+		return h
+	}
+	return s[mALL]
+}
+
+// RouteInfo describes a single method and route visited by WalkRoutes.
+type RouteInfo struct {
+	// Handler is the endpoint handler, without the inline middlewares of the route.
+	Handler http.Handler
+
+	// Meta is the metadata of the route, merged with the metadata of the mounts it is routed through.
+	Meta Meta
+
+	// Method is the HTTP method of the route.
+	Method string
+
+	// Host is the host pattern of the host router serving the route, if any.
+	Host string
+
+	// Pattern is the full routing pattern of the route, including the mount patterns.
+	Pattern string
+
+	// Predicates the requests must match to be served by the route, see Where.
+	// The routes of a method and pattern are visited in the order their predicates are evaluated.
+	Predicates []Predicate
+
+	// Middlewares is the middleware stack the route is served through.
+	Middlewares Middlewares
+}
+
 // Walk walks any router tree that implements Routes interface.
+// Routes of host routers are reported with their host pattern prepended, e.g. "{tenant}.example.com/users".
 func Walk(r Routes, walkFn WalkFunc) error {
-	return walk(r, walkFn, "")
+	return WalkRoutes(r, func(rt RouteInfo) error {
+		return walkFn(rt.Method, rt.Host+rt.Pattern, rt.Handler, rt.Middlewares...)
+	})
 }
 
-func walk(r Routes, walkFn WalkFunc, parentRoute string, parentMw ...func(http.Handler) http.Handler) (err error) {
+// WalkRoutes walks any router tree that implements Routes interface, like Walk,
+// describing each of the visited routes with a RouteInfo.
+func WalkRoutes(r Routes, fn func(rt RouteInfo) error) error {
+	return walk(r, fn, "", "", nil)
+}
+
+func walk(r Routes, fn func(rt RouteInfo) error, parentHost, parentRoute string, parentMeta Meta, parentMw ...func(http.Handler) http.Handler) (err error) {
 	for _, route := range r.Routes() {
 		mws := make([]func(http.Handler) http.Handler, len(parentMw))
 		copy(mws, parentMw)
 		mws = append(mws, r.Middlewares()...)
 
+		host := parentHost
+		if route.Host != "" {
+			host = route.Host
+		}
+
 		if route.SubRoutes != nil {
-			if err = walk(route.SubRoutes, walkFn, parentRoute+route.Pattern, mws...); err != nil {
+			meta := mergeMeta(parentMeta, route.Meta["*"])
+			subRoute := parentRoute + strings.TrimSuffix(route.Pattern, "/*")
+			if err = walk(route.SubRoutes, fn, host, subRoute, meta, mws...); err != nil {
 				return
 			}
 			continue
@@ -706,14 +1039,23 @@ func walk(r Routes, walkFn WalkFunc, parentRoute string, parentMw ...func(http.H
 			}
 
 			fullRoute := parentRoute + route.Pattern
-			fullRoute = strings.Replace(fullRoute, "/*/", "/", -1)
 
-			if chain, ok := handler.(*ChainHandler); ok {
-				if err = walkFn(method, fullRoute, chain.Endpoint, append(mws, chain.Middlewares...)...); err != nil {
-					return
+			for _, v := range routeVariants(handler, route.Meta[method]) {
+				rt := RouteInfo{
+					Handler:     v.handler,
+					Meta:        mergeMeta(parentMeta, v.meta),
+					Method:      method,
+					Host:        host,
+					Pattern:     fullRoute,
+					Middlewares: mws,
+					Predicates:  v.predicates,
 				}
-			} else {
-				if err = walkFn(method, fullRoute, handler, mws...); err != nil {
+				if chain, ok := v.handler.(*ChainHandler); ok {
+					rt.Handler = chain.Endpoint
+					rt.Middlewares = append(mws[:len(mws):len(mws)], chain.Middlewares...)
+				}
+
+				if err = fn(rt); err != nil {
 					return
 				}
 			}
@@ -723,15 +1065,6 @@ func walk(r Routes, walkFn WalkFunc, parentRoute string, parentMw ...func(http.H
 	return nil
 }
 
-func methodTypeString(method methodType) string {
-	for s, t := range methodMap {
-		if method == t {
-			return s
-		}
-	}
-	return ""
-}
-
 // longestPrefix finds the length of the shared prefix of two strings
 func longestPrefix(k1, k2 string) int {
 	var i int
@@ -751,21 +1084,25 @@ func longestPrefix(k1, k2 string) int {
 // patNextSegment returns the next segment details from a pattern:
 // node type, param key, regexp string, param tail byte, param starting index, param ending index
 func patNextSegment(pattern string) (nodeType, string, string, byte, int, int) {
+	ntyp, key, rexpat, tail, ps, pe, err := patParseSegment(pattern)
+	if err != nil {
+		panic(err)
+	}
+	return ntyp, key, rexpat, tail, ps, pe
+}
+
+// patParseSegment is patNextSegment returning a *RouteError located in `pattern` for invalid segments.
+func patParseSegment(pattern string) (nodeType, string, string, byte, int, int, *RouteError) {
 	ps := strings.Index(pattern, "{")
 	ws := strings.Index(pattern, "*")
 
 	if ps < 0 && ws < 0 {
-		return ntStatic, "", "", 0, 0, len(pattern) // we return the entire thing
-	}
-
-	// Sanity check
-	if ps >= 0 && ws >= 0 && ws < ps {
-		panic("gor: wildcard '*' must be the last pattern in a route, otherwise use a '{param}'")
+		return ntStatic, "", "", 0, 0, len(pattern), nil // we return the entire thing
 	}
 
 	var tail byte = '/' // default endpoint tail to / byte
 
-	if ps >= 0 {
+	if ps >= 0 && (ws < 0 || ps < ws) {
 		// Param/Regexp pattern is next
 		nt := ntParam
 
@@ -784,12 +1121,26 @@ func patNextSegment(pattern string) (nodeType, string, string, byte, int, int) {
 			}
 		}
 		if pe == ps {
-			panic("gor: route param closing delimiter '}' is missing")
+			return 0, "", "", 0, 0, 0, patternError(ErrInvalidPattern, pattern, ps, "route param closing delimiter '}' is missing")
 		}
 
 		key := pattern[ps+1 : pe]
 		pe++ // set end to next position
 
+		if strings.HasSuffix(key, "...") && !strings.Contains(key, ":") {
+			// named wildcard pattern, as finale or spanning whole segments
+			key = strings.TrimSuffix(key, "...")
+			if key == "" || strings.ContainsAny(key, "{}") {
+				return 0, "", "", 0, 0, 0, patternError(ErrInvalidPattern, pattern, ps,
+					"invalid wildcard param '%s', use a '{name...}' instead", pattern[ps:pe])
+			}
+			if pe < len(pattern) && pattern[pe] != '/' {
+				return 0, "", "", 0, 0, 0, patternError(ErrInvalidPattern, pattern, pe,
+					"wildcard param '{%s...}' must be the last value in a route or be followed by a '/'", key)
+			}
+			return ntCatchAll, key, "", 0, ps, pe, nil
+		}
+
 		if pe < len(pattern) {
 			tail = pattern[pe]
 		}
@@ -800,8 +1151,10 @@ func patNextSegment(pattern string) (nodeType, string, string, byte, int, int) {
 			rexpat = key[idx+1:]
 			key = key[:idx]
 		}
+		key = strings.TrimSuffix(key, "?") // optional params
 
-		if len(rexpat) > 0 {
+		// registered param type names are kept as is, anything else is a regexp
+		if _, ok := lookupParamType(rexpat); !ok && len(rexpat) > 0 {
 			if rexpat[0] != '^' {
 				rexpat = "^" + rexpat
 			}
@@ -810,52 +1163,122 @@ func patNextSegment(pattern string) (nodeType, string, string, byte, int, int) {
 			}
 		}
 
-		return nt, key, rexpat, tail, ps, pe
+		return nt, key, rexpat, tail, ps, pe, nil
 	}
 
-	// wildcard pattern as finale
-	if ws < len(pattern)-1 {
-		panic("gor: wildcard '*' must be the last value in a route. trim trailing text or use a '{param}' instead")
+	// wildcard pattern as finale or spanning whole segments
+	if ws < len(pattern)-1 && pattern[ws+1] != '/' {
+		return 0, "", "", 0, 0, 0, patternError(ErrInvalidPattern, pattern, ws,
+			"wildcard '*' must be the last value in a route or be followed by a '/'. trim trailing text or use a '{param}' instead")
 	}
-	return ntCatchAll, "*", "", 0, ws, len(pattern)
+	return ntCatchAll, "*", "", 0, ws, ws + 1, nil
 }
 
-func patParamKeys(pattern string) []string {
-	pat := pattern
-	paramKeys := []string{}
-	for {
-		ptyp, paramKey, _, _, _, e := patNextSegment(pat)
-		if ptyp == ntStatic {
-			return paramKeys
+// patValidate checks a routing pattern, returning a *RouteError locating the first problem of the pattern:
+// an invalid param, wildcard or regexp, a duplicate param key or a misplaced optional param.
+func patValidate(pattern string) error {
+	if pattern == "" || pattern[0] != '/' {
+		return patternError(ErrInvalidPattern, pattern, 0, "routing pattern must begin with '/'")
+	}
+
+	var paramKeys []string
+	optional := -1 // position of the first optional param
+
+	for off := 0; ; {
+		segTyp, key, rexpat, _, ps, pe, err := patParseSegment(pattern[off:])
+		if err != nil {
+			err.Pattern = pattern
+			err.Pos += off
+			return err
 		}
-		for i := 0; i < len(paramKeys); i++ {
-			if paramKeys[i] == paramKey {
-				panic(fmt.Sprintf("gor: routing pattern '%s' contains duplicate param key, '%s'", pattern, paramKey))
+
+		if segTyp == ntStatic {
+			if optional >= 0 && off < len(pattern) {
+				return patternError(ErrInvalidPattern, pattern, off, "optional params must be the last values in a route")
 			}
+			return nil
 		}
-		paramKeys = append(paramKeys, paramKey)
-		pat = pat[e:]
+
+		ps += off
+		pe += off
+
+		for _, k := range paramKeys {
+			if k == key {
+				return patternError(ErrDuplicateParam, pattern, ps, "routing pattern contains duplicate param key, '%s'", key)
+			}
+		}
+		paramKeys = append(paramKeys, key)
+
+		if segTyp == ntRegexp {
+			if _, ok := lookupParamType(rexpat); !ok {
+				if _, err := regexp.Compile(rexpat); err != nil {
+					return patternError(ErrInvalidPattern, pattern, ps, "invalid regexp pattern '%s' in route param", rexpat)
+				}
+			}
+		}
+
+		if patOptional(pattern[ps:pe], key) {
+			if pattern[ps-1] != '/' || (pe < len(pattern) && pattern[pe] != '/') {
+				return patternError(ErrInvalidPattern, pattern, ps, "optional param '%s' must span a whole path segment", key)
+			}
+			if optional < 0 {
+				optional = ps
+			}
+		} else if optional >= 0 {
+			return patternError(ErrInvalidPattern, pattern, ps, "optional params must be the last values in a route")
+		}
+
+		off = pe
 	}
 }
 
-// RegisterMethod adds support for custom HTTP method handlers
-func RegisterMethod(method string) {
-	if method == "" {
-		return
-	}
+// patOptional reports whether the param `segment` of a pattern with the `key` is optional, i.e. `{key?}` or `{key?:regexp}`.
+func patOptional(segment, key string) bool {
+	return len(segment) > len(key)+1 && segment[0] == '{' && segment[len(key)+1] == '?'
+}
 
-	method = strings.ToUpper(method)
+// patExpandOptional expands the trailing optional params of a valid pattern into the patterns
+// matching each number of present params, from none to all of them, e.g.
+// "/reports/{year?}/{month?:[0-9]+}" is expanded into "/reports", "/reports/{year}" and "/reports/{year}/{month:[0-9]+}".
+func patExpandOptional(pattern string) []string {
+	var patterns []string
+	var b strings.Builder
+	search := pattern
 
-	if _, ok := methodMap[method]; ok {
-		return
-	}
+	for {
+		segTyp, key, _, _, ps, pe := patNextSegment(search)
+		if segTyp == ntStatic {
+			b.WriteString(search)
+			return append(patterns, b.String())
+		}
 
-	n := len(methodMap)
-	if n > strconv.IntSize-2 {
-		panic(fmt.Sprintf("gor: max number of methods reached (%d)", strconv.IntSize))
+		segment := search[ps:pe]
+		if patOptional(segment, key) {
+			// the route without this and the next params
+			prefix := strings.TrimSuffix(b.String()+search[:ps], "/")
+			if prefix == "" {
+				prefix = "/"
+			}
+			patterns = append(patterns, prefix)
+
+			segment = segment[:len(key)+1] + segment[len(key)+2:]
+		}
+
+		b.WriteString(search[:ps])
+		b.WriteString(segment)
+		search = search[pe:]
 	}
+}
 
-	mt := methodType(2 << n)
-	methodMap[method] = mt
-	mALL |= mt
+func patParamKeys(pattern string) []string {
+	pat := pattern
+	paramKeys := []string{}
+	for {
+		ptyp, paramKey, _, _, _, e := patNextSegment(pat)
+		if ptyp == ntStatic {
+			return paramKeys
+		}
+		paramKeys = append(paramKeys, paramKey)
+		pat = pat[e:]
+	}
 }
@@ -394,6 +394,76 @@ func TestTreeRegexpMatchWholeParam(t *testing.T) {
 	}
 }
 
+func TestTreeWildcardSegments(t *testing.T) {
+	hFileMeta := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	hFileID := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	hFileLatest := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	hFiles := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	hBlobRaw := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	hBlob := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	hDocs := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	tr := &node{}
+	tr.InsertRoute(mGET, "/files/*/metadata", hFileMeta)
+	tr.InsertRoute(mGET, "/files/{id}/metadata", hFileID)
+	tr.InsertRoute(mGET, "/files/latest/metadata", hFileLatest)
+	tr.InsertRoute(mGET, "/files/*", hFiles)
+	tr.InsertRoute(mGET, "/repos/{owner}/{repo}/blob/{ref}/{path...}/raw", hBlobRaw)
+	tr.InsertRoute(mGET, "/repos/{owner}/{repo}/blob/{ref}/{path...}", hBlob)
+	tr.InsertRoute(mGET, "/docs/{path...}/edit/{path2...}", hDocs)
+
+	tests := []struct {
+		r string       // input request path
+		h http.Handler // output matched handler
+		k []string     // output param keys
+		v []string     // output param values
+	}{
+		{r: "/files/latest/metadata", h: hFileLatest, k: []string{}, v: []string{}},
+		{r: "/files/42/metadata", h: hFileID, k: []string{"id"}, v: []string{"42"}},
+		{r: "/files/a/b/metadata", h: hFileMeta, k: []string{"*"}, v: []string{"a/b"}},
+		{r: "/files/a/metadata/b/metadata", h: hFileMeta, k: []string{"*"}, v: []string{"a/metadata/b"}},
+		{r: "/files/a/b/metadata/x", h: hFiles, k: []string{"*"}, v: []string{"a/b/metadata/x"}},
+		{r: "/files//metadata", h: hFileID, k: []string{"id"}, v: []string{""}},
+		{r: "/files/", h: hFiles, k: []string{"*"}, v: []string{""}},
+
+		{r: "/repos/go/gor/blob/main/docs/a.md/raw", h: hBlobRaw,
+			k: []string{"owner", "repo", "ref", "path"}, v: []string{"go", "gor", "main", "docs/a.md"}},
+		{r: "/repos/go/gor/blob/main/docs/a.md", h: hBlob,
+			k: []string{"owner", "repo", "ref", "path"}, v: []string{"go", "gor", "main", "docs/a.md"}},
+		{r: "/repos/go/gor/blob/main/raw", h: hBlob,
+			k: []string{"owner", "repo", "ref", "path"}, v: []string{"go", "gor", "main", "raw"}},
+
+		{r: "/docs/a/edit/b/edit/c", h: hDocs, k: []string{"path", "path2"}, v: []string{"a/edit/b", "c"}},
+		{r: "/docs/a/edit", h: nil, k: []string{}, v: []string{}},
+	}
+
+	for i, tt := range tests {
+		rctx := NewRouteContext()
+
+		_, handlers, _ := tr.FindRoute(rctx, mGET, tt.r)
+
+		var handler http.Handler
+		if methodHandler, ok := handlers[mGET]; ok {
+			handler = methodHandler.handler
+		}
+
+		paramKeys := rctx.routeParams.Keys
+		paramValues := rctx.routeParams.Values
+
+		if fmt.Sprintf("%v", tt.h) != fmt.Sprintf("%v", handler) {
+			t.Errorf("input [%d]: find '%s' expecting handler:%v , got:%v", i, tt.r, tt.h, handler)
+		}
+
+		if !stringSliceEqual(tt.k, paramKeys) {
+			t.Errorf("input [%d]: find '%s' expecting paramKeys:(%d)%v , got:(%d)%v", i, tt.r, len(tt.k), tt.k, len(paramKeys), paramKeys)
+		}
+
+		if !stringSliceEqual(tt.v, paramValues) {
+			t.Errorf("input [%d]: find '%s' expecting paramValues:(%d)%v , got:(%d)%v", i, tt.r, len(tt.v), tt.v, len(paramValues), paramValues)
+		}
+	}
+}
+
 func TestTreeFindPattern(t *testing.T) {
 	hStub1 := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
 	hStub2 := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
@@ -467,6 +537,44 @@ func BenchmarkTreeGet(b *testing.B) {
 	}
 }
 
+// BenchmarkTreeStaticIndex compares the lookup of a static route in a large routing table
+// through the static routes index with the traversal of the tree.
+func BenchmarkTreeStaticIndex(b *testing.B) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+
+	tr := &node{}
+	for i := 0; i < 500; i++ {
+		tr.InsertRoute(mGET, fmt.Sprintf("/api/v1/resource%d", i), h)
+		tr.InsertRoute(mGET, fmt.Sprintf("/api/v1/resource%d/{id}", i), h)
+		tr.InsertRoute(mGET, fmt.Sprintf("/api/v1/resource%d/{id}/items/summary", i), h)
+		tr.InsertRoute(mGET, fmt.Sprintf("/api/v1/resource%d/items/summary", i), h)
+	}
+	tr.InsertRoute(mGET, "/healthz", h)
+
+	// the copies of the trees have no index until they are reindexed
+	noIndex := tr.clone()
+
+	for _, path := range []string{"/healthz", "/api/v1/resource499/items/summary"} {
+		b.Run("index"+path, func(b *testing.B) {
+			rctx := NewRouteContext()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				rctx.Reset()
+				tr.FindRoute(rctx, mGET, path)
+			}
+		})
+
+		b.Run("traversal"+path, func(b *testing.B) {
+			rctx := NewRouteContext()
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				rctx.Reset()
+				noIndex.FindRoute(rctx, mGET, path)
+			}
+		})
+	}
+}
+
 func debugPrintTree(parent int, i int, n *node, label byte) bool {
 	numEdges := 0
 	for _, nds := range n.child {
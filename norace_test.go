@@ -0,0 +1,5 @@
+//go:build !race
+
+package gor
+
+const raceEnabled = false
@@ -0,0 +1,283 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/mail"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pchchv/gor"
+)
+
+// checker validates values against the schemas of a document, collecting the violations.
+type checker struct {
+	doc      *Document
+	patterns map[string]*regexp.Regexp
+
+	// in and name locate the validated value, e.g. "query" and "limit"
+	in   string
+	name string
+
+	errs []*ValidationError
+}
+
+func (c *checker) errorf(ptr, format string, args ...interface{}) {
+	c.errs = append(c.errs, &ValidationError{In: c.in, Name: c.name, Pointer: ptr, Detail: fmt.Sprintf(format, args...)})
+}
+
+// valid reports whether the value matches the schema, without collecting the violations.
+func (c *checker) valid(s *Schema, v interface{}) bool {
+	sub := &checker{doc: c.doc, patterns: c.patterns}
+	sub.check(s, v, "")
+	return len(sub.errs) == 0
+}
+
+// check validates the value decoded by encoding/json, located by the JSON pointer `ptr`, against the schema.
+func (c *checker) check(s *Schema, v interface{}, ptr string) {
+	s = c.doc.schema(s)
+	if s == nil {
+		return
+	}
+
+	for _, sub := range s.AllOf {
+		c.check(sub, v, ptr)
+	}
+	if len(s.AnyOf) > 0 {
+		matched := false
+		for _, sub := range s.AnyOf {
+			if matched = c.valid(sub, v); matched {
+				break
+			}
+		}
+		if !matched {
+			c.errorf(ptr, "value does not match any of the anyOf schemas")
+		}
+	}
+	if len(s.OneOf) > 0 {
+		n := 0
+		for _, sub := range s.OneOf {
+			if c.valid(sub, v) {
+				n++
+			}
+		}
+		if n != 1 {
+			c.errorf(ptr, "value matches %d of the oneOf schemas, expecting exactly one", n)
+		}
+	}
+	if s.Not != nil && c.valid(s.Not, v) {
+		c.errorf(ptr, "value must not match the not schema")
+	}
+
+	if v == nil {
+		if s.Type != "" && s.Type != "null" && !s.Nullable {
+			c.errorf(ptr, "expecting %s, got null", s.Type)
+		}
+		return
+	}
+	if t := jsonType(v); s.Type != "" && t != s.Type && !(s.Type == "number" && t == "integer") {
+		c.errorf(ptr, "expecting %s, got %s", s.Type, t)
+		return
+	}
+
+	if len(s.Enum) > 0 && !inEnum(s.Enum, v) {
+		c.errorf(ptr, "value is not one of %s", enumString(s.Enum))
+	}
+
+	switch v := v.(type) {
+	case string:
+		c.checkString(s, v, ptr)
+	case json.Number:
+		c.checkNumber(s, v, ptr)
+	case []interface{}:
+		if s.MinItems != nil && len(v) < *s.MinItems {
+			c.errorf(ptr, "expecting at least %d items, got %d", *s.MinItems, len(v))
+		}
+		if s.MaxItems != nil && len(v) > *s.MaxItems {
+			c.errorf(ptr, "expecting at most %d items, got %d", *s.MaxItems, len(v))
+		}
+		if s.Items != nil {
+			for i, item := range v {
+				c.check(s.Items, item, ptr+"/"+strconv.Itoa(i))
+			}
+		}
+	case map[string]interface{}:
+		c.checkObject(s, v, ptr)
+	}
+}
+
+func (c *checker) checkString(s *Schema, v, ptr string) {
+	n := utf8.RuneCountInString(v)
+	if s.MinLength != nil && n < *s.MinLength {
+		c.errorf(ptr, "expecting at least %d characters, got %d", *s.MinLength, n)
+	}
+	if s.MaxLength != nil && n > *s.MaxLength {
+		c.errorf(ptr, "expecting at most %d characters, got %d", *s.MaxLength, n)
+	}
+	if s.Pattern != "" && !c.patterns[s.Pattern].MatchString(v) {
+		c.errorf(ptr, "value does not match the pattern %s", s.Pattern)
+	}
+
+	var err error
+	switch s.Format {
+	case "date-time":
+		_, err = time.Parse(time.RFC3339, v)
+	case "date":
+		_, err = time.Parse(time.DateOnly, v)
+	case "uuid":
+		_, err = gor.ParseUUID(v)
+	case "email":
+		_, err = mail.ParseAddress(v)
+	}
+	if err != nil {
+		c.errorf(ptr, "value is not a valid %s", s.Format)
+	}
+}
+
+func (c *checker) checkNumber(s *Schema, v json.Number, ptr string) {
+	f, err := v.Float64()
+	if err != nil {
+		c.errorf(ptr, "invalid number %s", v)
+		return
+	}
+
+	switch {
+	case s.Minimum != nil && f < *s.Minimum:
+		c.errorf(ptr, "expecting a value of at least %v, got %s", *s.Minimum, v)
+	case s.ExclusiveMinimum != nil && f <= *s.ExclusiveMinimum:
+		c.errorf(ptr, "expecting a value greater than %v, got %s", *s.ExclusiveMinimum, v)
+	case s.Maximum != nil && f > *s.Maximum:
+		c.errorf(ptr, "expecting a value of at most %v, got %s", *s.Maximum, v)
+	case s.ExclusiveMaximum != nil && f >= *s.ExclusiveMaximum:
+		c.errorf(ptr, "expecting a value less than %v, got %s", *s.ExclusiveMaximum, v)
+	}
+}
+
+func (c *checker) checkObject(s *Schema, v map[string]interface{}, ptr string) {
+	for _, name := range s.Required {
+		if _, ok := v[name]; !ok {
+			c.errorf(ptr+"/"+escapePointer(name), "missing required property %q", name)
+		}
+	}
+
+	for _, name := range sortedKeys(v) {
+		propPtr := ptr + "/" + escapePointer(name)
+		if prop, ok := s.Properties[name]; ok {
+			c.check(prop, v[name], propPtr)
+			continue
+		}
+
+		switch ap := s.AdditionalProperties; {
+		case ap == nil:
+		case ap.Not != nil && reflect.DeepEqual(*ap.Not, Schema{}):
+			c.errorf(propPtr, "unexpected property %q", name)
+		default:
+			c.check(ap, v[name], propPtr)
+		}
+	}
+}
+
+// schema returns the schema referenced by a schema, if any.
+func (d *Document) schema(s *Schema) *Schema {
+	for i := 0; s != nil && s.Ref != "" && i < 32; i++ {
+		s = d.component(s.Ref, "schemas").(*Schema)
+	}
+	return s
+}
+
+// component returns the component referenced by `ref` in the `kind` components, e.g. "parameters",
+// or a nil pointer of the component type if there is none.
+func (d *Document) component(ref, kind string) interface{} {
+	name, _ := strings.CutPrefix(ref, "#/components/"+kind+"/")
+	name = strings.NewReplacer("~1", "/", "~0", "~").Replace(name)
+
+	c := d.Components
+	if c == nil {
+		c = &Components{}
+	}
+	switch kind {
+	case "schemas":
+		return c.Schemas[name]
+	case "parameters":
+		return c.Parameters[name]
+	case "requestBodies":
+		return c.RequestBodies[name]
+	case "responses":
+		return c.Responses[name]
+	}
+	return nil
+}
+
+// jsonType returns the JSON schema type of a value decoded by encoding/json.
+func jsonType(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		if f, err := v.Float64(); err == nil && f == math.Trunc(f) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	}
+	return "object"
+}
+
+func inEnum(enum []interface{}, v interface{}) bool {
+	for _, e := range enum {
+		if equalValues(e, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalValues compares JSON values, the numbers by value whether they are decoded as json.Number or float64.
+func equalValues(a, b interface{}) bool {
+	if fa, ok := toFloat(a); ok {
+		fb, ok := toFloat(b)
+		return ok && fa == fb
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func enumString(enum []interface{}) string {
+	b, _ := json.Marshal(enum)
+	return string(b)
+}
+
+// escapePointer escapes a JSON pointer reference token.
+func escapePointer(s string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(s)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,296 @@
+// Package openapi generates the OpenAPI 3.1 document of the routes of a gor router,
+// described by the Op metadata attached to them, e.g.
+//
+//	r.Meta(openapi.Describe(openapi.Op{
+//		Summary:   "Get a user",
+//		Tags:      []string{"users"},
+//		Responses: map[int]interface{}{200: User{}, 404: nil},
+//	})).Get("/users/{id:int}", getUser)
+//
+//	r.Get("/openapi.json", openapi.Handler(r, openapi.Config{Title: "Users", Version: "1.0.0"}).ServeHTTP)
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pchchv/gor"
+)
+
+// MetaKey is the gor.Meta key of the Op describing a route.
+const MetaKey = "openapi"
+
+// Op describes the operation of a route.
+type Op struct {
+	Summary     string
+	Description string
+	OperationID string
+	Tags        []string
+
+	// Params are the descriptions of the path params, keyed by name.
+	Params map[string]string
+
+	// Request is a value of the Go type of the JSON request body, if any.
+	Request interface{}
+
+	// Responses are values of the Go types of the JSON response bodies, keyed by status code.
+	// A nil value describes a response without body.
+	Responses map[int]interface{}
+
+	// Security are the security requirements of the operation, overriding the ones of the document.
+	Security []SecurityRequirement
+
+	Deprecated bool
+
+	// Hidden excludes the route from the document.
+	Hidden bool
+}
+
+// Describe returns the metadata describing a route with the Op, see gor.Mux.Meta.
+func Describe(op Op) gor.Meta {
+	return gor.Meta{MetaKey: op}
+}
+
+// Config describes the API of the document.
+type Config struct {
+	Title       string
+	Version     string
+	Description string
+	Servers     []Server
+
+	// SecuritySchemes of the API, keyed by name.
+	SecuritySchemes map[string]*SecurityScheme
+
+	// Security are the security requirements of the operations which do not override them.
+	Security []SecurityRequirement
+}
+
+// Generate walks the router and its sub-routers to describe their routes in an OpenAPI document.
+// The path params become the parameters of the operations: the regexp params are documented with their pattern,
+// the int, uint, uuid and date params with their type, and the wildcards as params named "wildcard", or after the
+// name of a `{name...}` wildcard, matching the rest of the path. The routes with optional params are described
+// as one path for each number of present params. The CONNECT routes are not described.
+func Generate(r gor.Routes, cfg Config) (*Document, error) {
+	doc := &Document{
+		OpenAPI:  "3.1.0",
+		Info:     Info{Title: cfg.Title, Version: cfg.Version, Description: cfg.Description},
+		Servers:  cfg.Servers,
+		Paths:    make(map[string]PathItem),
+		Security: cfg.Security,
+	}
+	s := newSchemas()
+
+	err := gor.WalkRoutes(r, func(rt gor.RouteInfo) error {
+		if rt.Method == http.MethodConnect {
+			return nil
+		}
+
+		op, _ := rt.Meta[MetaKey].(Op)
+		if op.Hidden {
+			return nil
+		}
+
+		for _, p := range expandPath(rt.Pattern) {
+			item := doc.Paths[p.path]
+			if item == nil {
+				item = make(PathItem)
+				doc.Paths[p.path] = item
+			}
+			item[strings.ToLower(rt.Method)] = operation(op, p.params, s)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(s.components) > 0 || len(cfg.SecuritySchemes) > 0 {
+		doc.Components = &Components{SecuritySchemes: cfg.SecuritySchemes}
+		if len(s.components) > 0 {
+			doc.Components.Schemas = s.components
+		}
+	}
+
+	return doc, nil
+}
+
+func operation(op Op, params []*Parameter, s *schemas) *Operation {
+	o := &Operation{
+		Summary:     op.Summary,
+		Description: op.Description,
+		OperationID: op.OperationID,
+		Tags:        op.Tags,
+		Responses:   make(map[string]*Response),
+		Security:    op.Security,
+		Deprecated:  op.Deprecated,
+	}
+
+	for _, p := range params {
+		p := *p
+		p.Description = op.Params[p.Name]
+		o.Parameters = append(o.Parameters, &p)
+	}
+
+	if op.Request != nil {
+		o.RequestBody = &RequestBody{
+			Required: true,
+			Content:  map[string]MediaType{"application/json": {Schema: s.schema(op.Request)}},
+		}
+	}
+
+	for status, body := range op.Responses {
+		resp := &Response{Description: http.StatusText(status)}
+		if body != nil {
+			resp.Content = map[string]MediaType{"application/json": {Schema: s.schema(body)}}
+		}
+		o.Responses[strconv.Itoa(status)] = resp
+	}
+	if len(o.Responses) == 0 {
+		o.Responses["200"] = &Response{Description: http.StatusText(http.StatusOK)}
+	}
+
+	return o
+}
+
+// JSON encodes the document as indented JSON.
+func (d *Document) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// YAML encodes the document as YAML.
+func (d *Document) YAML() ([]byte, error) {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	return jsonToYAML(b)
+}
+
+// Handler returns a handler serving the OpenAPI document of the router, generated for each request
+// so that it reflects the routes changed at runtime. The document is served as YAML for the paths ending
+// with ".yaml" or ".yml" and the requests preferring "application/yaml", and as JSON otherwise.
+func Handler(r gor.Routes, cfg Config) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		doc, err := Generate(r, cfg)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		asYAML := strings.HasSuffix(req.URL.Path, ".yaml") || strings.HasSuffix(req.URL.Path, ".yml") ||
+			gor.NegotiateContentType(req, "application/json", "application/yaml") == "application/yaml"
+
+		var b []byte
+		if asYAML {
+			b, err = doc.YAML()
+			w.Header().Set("Content-Type", "application/yaml")
+		} else {
+			b, err = doc.JSON()
+			w.Header().Set("Content-Type", "application/json")
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		w.Write(b)
+	})
+}
+
+// openAPIPath is an OpenAPI path, e.g. "/users/{id}", along with its params.
+type openAPIPath struct {
+	path   string
+	params []*Parameter
+}
+
+// expandPath translates a gor routing pattern into the OpenAPI paths of its optional params expansions,
+// from the shortest one to the longest one.
+func expandPath(pattern string) []openAPIPath {
+	var paths []openAPIPath
+	var b strings.Builder
+	var params []*Parameter
+
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '*':
+			b.WriteString("{wildcard}")
+			params = append(params, &Parameter{Name: "wildcard", In: "path", Required: true, Schema: &Schema{Type: "string"}})
+
+		case '{':
+			end := paramEnd(pattern, i)
+			if end < 0 {
+				b.WriteString(pattern[i:])
+				i = len(pattern)
+				continue
+			}
+
+			key, rexpat, _ := strings.Cut(pattern[i+1:end], ":")
+			key = strings.TrimSuffix(key, "...")
+			if optional := strings.HasSuffix(key, "?"); optional {
+				key = strings.TrimSuffix(key, "?")
+				paths = append(paths, openAPIPath{path: trimPath(b.String()), params: params[:len(params):len(params)]})
+			}
+
+			b.WriteString("{" + key + "}")
+			params = append(params, &Parameter{Name: key, In: "path", Required: true, Schema: paramSchema(rexpat)})
+			i = end
+
+		default:
+			b.WriteByte(pattern[i])
+		}
+	}
+
+	return append(paths, openAPIPath{path: b.String(), params: params})
+}
+
+// paramEnd returns the index of the brace closing the param starting at `start`, or -1.
+func paramEnd(pattern string, start int) int {
+	depth := 0
+	for i := start; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '{':
+			depth++
+		case '}':
+			if depth--; depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func trimPath(p string) string {
+	if p = strings.TrimSuffix(p, "/"); p == "" {
+		return "/"
+	}
+	return p
+}
+
+// paramSchema returns the schema of a path param of a param type or a regexp, if any.
+func paramSchema(rexpat string) *Schema {
+	switch rexpat {
+	case "":
+		return &Schema{Type: "string"}
+	case "int":
+		return &Schema{Type: "integer", Format: "int64"}
+	case "uint":
+		zero := 0.0
+		return &Schema{Type: "integer", Minimum: &zero}
+	case "uuid":
+		return &Schema{Type: "string", Format: "uuid"}
+	case "date":
+		return &Schema{Type: "string", Format: "date"}
+	case "alpha":
+		return &Schema{Type: "string", Pattern: "^[A-Za-z]+$"}
+	}
+
+	if rexpat[0] != '^' {
+		rexpat = "^" + rexpat
+	}
+	if rexpat[len(rexpat)-1] != '$' {
+		rexpat += "$"
+	}
+	return &Schema{Type: "string", Pattern: rexpat}
+}
@@ -0,0 +1,213 @@
+package openapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pchchv/gor"
+)
+
+type user struct {
+	ID      int       `json:"id"`
+	Name    string    `json:"name"`
+	Email   string    `json:"email,omitempty"`
+	Friends []*user   `json:"friends,omitempty"`
+	Created time.Time `json:"created"`
+	secret  string
+}
+
+type newUser struct {
+	Name  string            `json:"name"`
+	Attrs map[string]string `json:"attrs,omitempty"`
+	Note  *string           `json:"note"`
+	Debug bool              `json:"-"`
+}
+
+func testRouter() *gor.Mux {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := gor.NewRouter()
+	r.Route("/users", func(r gor.Router) {
+		r.Meta(Describe(Op{
+			Summary:   "List the users",
+			Tags:      []string{"users"},
+			Responses: map[int]interface{}{200: []user{}},
+		})).Get("/", h)
+		r.Meta(Describe(Op{
+			Summary:   "Create a user",
+			Request:   newUser{},
+			Responses: map[int]interface{}{201: &user{}, 400: nil},
+			Security:  []SecurityRequirement{{"bearer": {"users:write"}}},
+		})).Post("/", h)
+		r.Meta(Describe(Op{
+			Summary: "Get a user",
+			Params:  map[string]string{"id": "The user id."},
+		})).Get("/{id:int}", h)
+	})
+	r.Get("/articles/{slug:[a-z-]+}", h)
+	r.Get("/reports/{year}/{month?}", h)
+	r.Get("/files/{path...}", h)
+	r.Get("/static/*", h)
+	r.Meta(Describe(Op{Hidden: true})).Get("/internal", h)
+
+	return r
+}
+
+func testDocument(t *testing.T) *Document {
+	doc, err := Generate(testRouter(), Config{
+		Title:           "Users",
+		Version:         "1.0.0",
+		SecuritySchemes: map[string]*SecurityScheme{"bearer": {Type: "http", Scheme: "bearer"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestGenerate(t *testing.T) {
+	doc := testDocument(t)
+
+	if doc.OpenAPI != "3.1.0" || doc.Info.Title != "Users" || doc.Info.Version != "1.0.0" {
+		t.Fatalf("unexpected document %+v", doc)
+	}
+
+	var paths []string
+	for p := range doc.Paths {
+		paths = append(paths, p)
+	}
+	if len(paths) != 7 || doc.Paths["/internal"] != nil {
+		t.Fatalf("unexpected paths %v", paths)
+	}
+
+	list := doc.Paths["/users/"]["get"]
+	if list.Summary != "List the users" || list.Tags[0] != "users" {
+		t.Fatalf("unexpected operation %+v", list)
+	}
+	if s := list.Responses["200"].Content["application/json"].Schema; s.Type != "array" || s.Items.Ref != "#/components/schemas/user" {
+		t.Fatalf("unexpected response schema %+v", s)
+	}
+
+	create := doc.Paths["/users/"]["post"]
+	if create.RequestBody.Content["application/json"].Schema.Ref != "#/components/schemas/newUser" {
+		t.Fatalf("unexpected request body %+v", create.RequestBody)
+	}
+	if create.Responses["201"].Content == nil || create.Responses["400"].Content != nil || create.Responses["400"].Description != "Bad Request" {
+		t.Fatalf("unexpected responses %+v", create.Responses)
+	}
+	if scopes := create.Security[0]["bearer"]; len(scopes) != 1 || scopes[0] != "users:write" {
+		t.Fatalf("unexpected security %v", create.Security)
+	}
+
+	get := doc.Paths["/users/{id}"]["get"]
+	if p := get.Parameters[0]; p.Name != "id" || p.In != "path" || !p.Required || p.Description != "The user id." || p.Schema.Type != "integer" {
+		t.Fatalf("unexpected parameter %+v", p)
+	}
+	if get.Responses["200"].Description != "OK" {
+		t.Fatalf("unexpected responses %+v", get.Responses)
+	}
+
+	if p := doc.Paths["/articles/{slug}"]["get"].Parameters[0]; p.Schema.Pattern != "^[a-z-]+$" {
+		t.Fatalf("unexpected parameter %+v", p)
+	}
+	if p := doc.Paths["/files/{path}"]["get"].Parameters[0]; p.Name != "path" {
+		t.Fatalf("unexpected parameter %+v", p)
+	}
+	if p := doc.Paths["/static/{wildcard}"]["get"].Parameters[0]; p.Name != "wildcard" {
+		t.Fatalf("unexpected parameter %+v", p)
+	}
+	if len(doc.Paths["/reports/{year}"]["get"].Parameters) != 1 || len(doc.Paths["/reports/{year}/{month}"]["get"].Parameters) != 2 {
+		t.Fatalf("unexpected optional param expansions %v", paths)
+	}
+
+	u := doc.Components.Schemas["user"]
+	if len(u.Properties) != 5 || u.Properties["created"].Format != "date-time" || u.Properties["friends"].Items.Ref != "#/components/schemas/user" {
+		t.Fatalf("unexpected user schema %+v", u)
+	}
+	if strings.Join(u.Required, ",") != "id,name,created" {
+		t.Fatalf("unexpected required properties %v", u.Required)
+	}
+
+	nu := doc.Components.Schemas["newUser"]
+	if len(nu.Properties) != 3 || nu.Properties["attrs"].AdditionalProperties.Type != "string" || strings.Join(nu.Required, ",") != "name" {
+		t.Fatalf("unexpected newUser schema %+v", nu)
+	}
+
+	if doc.Components.SecuritySchemes["bearer"].Scheme != "bearer" {
+		t.Fatalf("unexpected security schemes %+v", doc.Components.SecuritySchemes)
+	}
+}
+
+func TestDocumentYAML(t *testing.T) {
+	b, err := testDocument(t).YAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	y := string(b)
+	for _, s := range []string{
+		"openapi: \"3.1.0\"\n",
+		"info:\n  title: Users\n  version: \"1.0.0\"\n",
+		"  /articles/{slug}:\n    get:\n      parameters:\n        -\n          in: path\n          name: slug\n          required: true\n          schema:\n            pattern: \"^[a-z-]+$\"\n            type: string\n",
+		"          bearer:\n            - \"users:write\"\n",
+		"        \"$ref\": \"#/components/schemas/user\"\n",
+	} {
+		if !strings.Contains(y, s) {
+			t.Fatalf("expecting %q in\n%s", s, y)
+		}
+	}
+}
+
+func TestJSONToYAML(t *testing.T) {
+	b, err := jsonToYAML([]byte(`{"b":[1,"true",{}],"a":{"x":null,"y":[],"z":"a: b"},"c":false}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "a:\n  x: null\n  y: []\n  z: \"a: b\"\nb:\n  - 1\n  - \"true\"\n  - {}\nc: false\n"
+	if string(b) != want {
+		t.Fatalf("expecting\n%s\ngot\n%s", want, b)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	r := testRouter()
+	r.Handle("/openapi.*", Handler(r, Config{Title: "Users", Version: "1.0.0"}))
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/openapi.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc Document
+	err = json.NewDecoder(resp.Body).Decode(&doc)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Header.Get("Content-Type") != "application/json" || doc.Paths["/users/{id}"]["get"].Summary != "Get a user" {
+		t.Fatalf("unexpected document %+v", doc)
+	}
+	if doc.Paths["/openapi.{wildcard}"] == nil {
+		t.Fatalf("expecting the document route in %v", doc.Paths)
+	}
+
+	for _, req := range []struct{ path, accept string }{{"/openapi.yaml", ""}, {"/openapi.json", "application/yaml"}} {
+		r, _ := http.NewRequest("GET", ts.URL+req.path, nil)
+		r.Header.Set("Accept", req.accept)
+		resp, err := http.DefaultClient.Do(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.Header.Get("Content-Type") != "application/yaml" {
+			t.Fatalf("expecting a YAML document for %+v, got %q", req, resp.Header.Get("Content-Type"))
+		}
+	}
+}
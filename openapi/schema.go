@@ -0,0 +1,143 @@
+package openapi
+
+import (
+	"encoding"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// schemas derives the schemas of Go types, registering the named struct types as components.
+type schemas struct {
+	components map[string]*Schema
+
+	// names of the registered types
+	names map[reflect.Type]string
+}
+
+func newSchemas() *schemas {
+	return &schemas{components: make(map[string]*Schema), names: make(map[reflect.Type]string)}
+}
+
+// schema returns the schema of the type of the value, as encoded by encoding/json.
+func (s *schemas) schema(v interface{}) *Schema {
+	if v == nil {
+		return nil
+	}
+	return s.typeSchema(reflect.TypeOf(v))
+}
+
+func (s *schemas) typeSchema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		zero := 0.0
+		return &Schema{Type: "integer", Minimum: &zero}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.typeSchema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.typeSchema(t.Elem())}
+	case reflect.Struct:
+		return s.structSchema(t)
+	}
+
+	// interfaces and the other kinds accept any value
+	return &Schema{}
+}
+
+// structSchema returns a reference to the component of a named struct type, or the schema of an anonymous one.
+func (s *schemas) structSchema(t reflect.Type) *Schema {
+	if t.Name() == "" {
+		return s.objectSchema(t)
+	}
+
+	if name, ok := s.names[t]; ok {
+		return &Schema{Ref: "#/components/schemas/" + name}
+	}
+
+	name := t.Name()
+	if _, taken := s.components[name]; taken {
+		name = path.Base(t.PkgPath()) + "." + name
+	}
+
+	// register the name first, for the recursive types
+	s.names[t] = name
+	s.components[name] = nil
+	s.components[name] = s.objectSchema(t)
+
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+// objectSchema returns the schema of the JSON object of a struct type.
+func (s *schemas) objectSchema(t reflect.Type) *Schema {
+	obj := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	s.addFields(obj, t)
+	return obj
+}
+
+func (s *schemas) addFields(obj *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		// the fields of the embedded structs are promoted
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				s.addFields(obj, ft)
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		obj.Properties[name] = s.typeSchema(f.Type)
+		if !strings.Contains(opts, "omitempty") && f.Type.Kind() != reflect.Pointer {
+			obj.Required = append(obj.Required, name)
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Document is an OpenAPI 3.1 document.
+type Document struct {
+	OpenAPI    string                `json:"openapi"`
+	Info       Info                  `json:"info"`
+	Servers    []Server              `json:"servers,omitempty"`
+	Paths      map[string]PathItem   `json:"paths"`
+	Components *Components           `json:"components,omitempty"`
+	Security   []SecurityRequirement `json:"security,omitempty"`
+}
+
+// Info is the metadata of the API.
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Server is a server of the API.
+type Server struct {
+	URL         string `json:"url"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem holds the operations of a path, keyed by lowercase method, e.g. "get".
+type PathItem map[string]*Operation
+
+// UnmarshalJSON decodes the operations of a path item, along with the parameters shared by them,
+// which are merged into the parameters of each operation. The other fields of the path item are ignored.
+func (p *PathItem) UnmarshalJSON(b []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+
+	var shared []*Parameter
+	if raw, ok := fields["parameters"]; ok {
+		if err := json.Unmarshal(raw, &shared); err != nil {
+			return err
+		}
+	}
+
+	*p = make(PathItem)
+	for method, raw := range fields {
+		switch method {
+		case "get", "put", "post", "delete", "options", "head", "patch", "trace":
+		default:
+			continue
+		}
+
+		op := new(Operation)
+		if err := json.Unmarshal(raw, op); err != nil {
+			return err
+		}
+		for _, param := range shared {
+			if !op.hasParameter(param) {
+				op.Parameters = append(op.Parameters, param)
+			}
+		}
+		(*p)[method] = op
+	}
+
+	return nil
+}
+
+// Operation is an operation of the API, i.e. a method of a path.
+type Operation struct {
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	OperationID string                `json:"operationId,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []SecurityRequirement `json:"security,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+}
+
+// hasParameter reports whether the operation overrides a path item parameter.
+func (o *Operation) hasParameter(p *Parameter) bool {
+	for _, op := range o.Parameters {
+		if (op.Ref != "" && op.Ref == p.Ref) || (op.Name == p.Name && op.In == p.In && p.Ref == "") {
+			return true
+		}
+	}
+	return false
+}
+
+// Parameter is a parameter of an operation.
+type Parameter struct {
+	Ref         string  `json:"$ref,omitempty"`
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema,omitempty"`
+}
+
+// RequestBody is the request body of an operation.
+type RequestBody struct {
+	Ref         string               `json:"$ref,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+// Response is a response of an operation.
+type Response struct {
+	Ref         string               `json:"$ref,omitempty"`
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType describes the content of a request or response body of a media type.
+type MediaType struct {
+	Schema *Schema `json:"schema,omitempty"`
+}
+
+// Components holds the reusable objects of the document, referenced by the "$ref" fields.
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	Parameters      map[string]*Parameter      `json:"parameters,omitempty"`
+	RequestBodies   map[string]*RequestBody    `json:"requestBodies,omitempty"`
+	Responses       map[string]*Response       `json:"responses,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme is a security scheme of the API, e.g. {Type: "http", Scheme: "bearer"}.
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Description  string `json:"description,omitempty"`
+	Name         string `json:"name,omitempty"`
+	In           string `json:"in,omitempty"`
+	Scheme       string `json:"scheme,omitempty"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+// SecurityRequirement maps the names of security schemes to the scopes required by an operation.
+type SecurityRequirement map[string][]string
+
+// Schema is a JSON schema, limited to the keywords supported by the validator.
+type Schema struct {
+	Ref         string        `json:"$ref,omitempty"`
+	Type        string        `json:"type,omitempty"`
+	Nullable    bool          `json:"-"`
+	Format      string        `json:"format,omitempty"`
+	Pattern     string        `json:"pattern,omitempty"`
+	Description string        `json:"description,omitempty"`
+	Enum        []interface{} `json:"enum,omitempty"`
+
+	MinLength        *int     `json:"minLength,omitempty"`
+	MaxLength        *int     `json:"maxLength,omitempty"`
+	Minimum          *float64 `json:"minimum,omitempty"`
+	Maximum          *float64 `json:"maximum,omitempty"`
+	ExclusiveMinimum *float64 `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum *float64 `json:"exclusiveMaximum,omitempty"`
+
+	Items    *Schema `json:"items,omitempty"`
+	MinItems *int    `json:"minItems,omitempty"`
+	MaxItems *int    `json:"maxItems,omitempty"`
+
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+
+	AllOf []*Schema `json:"allOf,omitempty"`
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+	OneOf []*Schema `json:"oneOf,omitempty"`
+	Not   *Schema   `json:"not,omitempty"`
+}
+
+// schemaJSON is the JSON encoding of a Schema, with the keywords whose forms differ between the OpenAPI versions.
+type schemaJSON struct {
+	*schemaAlias
+	Type                 interface{}     `json:"type,omitempty"`
+	Nullable             bool            `json:"nullable,omitempty"`
+	ExclusiveMinimum     json.RawMessage `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     json.RawMessage `json:"exclusiveMaximum,omitempty"`
+	AdditionalProperties json.RawMessage `json:"additionalProperties,omitempty"`
+}
+
+type schemaAlias Schema
+
+// MarshalJSON encodes the schema, the type of the nullable schemas as ["type", "null"].
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if !s.Nullable || s.Type == "" {
+		return json.Marshal((*schemaAlias)(s))
+	}
+
+	v := struct {
+		*schemaAlias
+		Type []string `json:"type"`
+	}{(*schemaAlias)(s), []string{s.Type, "null"}}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON decodes the schema of an OpenAPI 3.1 or 3.0 document: the type may be a list of types,
+// of which only one can be non-null, the exclusive bounds may be booleans qualifying minimum and maximum,
+// and additionalProperties may be a boolean.
+func (s *Schema) UnmarshalJSON(b []byte) error {
+	v := schemaJSON{schemaAlias: (*schemaAlias)(s)}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	s.Nullable = v.Nullable
+
+	switch t := v.Type.(type) {
+	case string:
+		s.Type = t
+	case []interface{}:
+		for _, tt := range t {
+			if tt == "null" {
+				s.Nullable = true
+			} else if name, ok := tt.(string); ok && s.Type == "" {
+				s.Type = name
+			} else {
+				return fmt.Errorf("openapi: unsupported schema type %s", b)
+			}
+		}
+	case nil:
+	default:
+		return fmt.Errorf("openapi: invalid schema type %v", t)
+	}
+
+	var err error
+	if s.ExclusiveMinimum, s.Minimum, err = exclusiveBound(v.ExclusiveMinimum, s.Minimum); err != nil {
+		return err
+	}
+	if s.ExclusiveMaximum, s.Maximum, err = exclusiveBound(v.ExclusiveMaximum, s.Maximum); err != nil {
+		return err
+	}
+
+	switch string(v.AdditionalProperties) {
+	case "", "true":
+	case "false":
+		s.AdditionalProperties = &Schema{Not: &Schema{}}
+	default:
+		s.AdditionalProperties = new(Schema)
+		return json.Unmarshal(v.AdditionalProperties, s.AdditionalProperties)
+	}
+
+	return nil
+}
+
+// exclusiveBound returns the exclusive and inclusive bounds of a schema from an exclusiveMinimum or
+// exclusiveMaximum keyword, which is the bound itself in OpenAPI 3.1 and a flag qualifying the bound in OpenAPI 3.0.
+func exclusiveBound(raw json.RawMessage, bound *float64) (exclusive, inclusive *float64, err error) {
+	switch string(raw) {
+	case "", "false":
+		return nil, bound, nil
+	case "true":
+		return bound, nil, nil
+	}
+
+	exclusive = new(float64)
+	if err := json.Unmarshal(raw, exclusive); err != nil {
+		return nil, nil, err
+	}
+	return exclusive, bound, nil
+}
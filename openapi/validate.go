@@ -0,0 +1,561 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pchchv/gor"
+)
+
+// operationKey is the gor.Meta key of the operations routed by a Validator.
+const operationKey = "openapi.operation"
+
+// Load reads the OpenAPI 3 document of a JSON file.
+//
+// Only JSON documents are supported: a YAML document, e.g. "openapi.yaml", is rejected with an error,
+// and is to be converted to JSON beforehand, e.g. with "yq -o json openapi.yaml > openapi.json".
+func Load(path string) (*Document, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(b)
+}
+
+// Parse decodes an OpenAPI 3 document in JSON. As for Load, the YAML documents are not supported.
+func Parse(b []byte) (*Document, error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(b), []byte("{")) {
+		return nil, errors.New("openapi: the document is not a JSON object, YAML documents are not supported")
+	}
+
+	doc := new(Document)
+	if err := json.Unmarshal(b, doc); err != nil {
+		return nil, fmt.Errorf("openapi: invalid document: %w", err)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("openapi: unsupported OpenAPI version %q", doc.OpenAPI)
+	}
+	return doc, nil
+}
+
+// ValidationError is a violation of the OpenAPI document by a request or a response.
+type ValidationError struct {
+	// In is the location of the invalid value: "path", "query", "header", "body" or "response".
+	In string `json:"in"`
+
+	// Name of the invalid param or header, if any.
+	Name string `json:"name,omitempty"`
+
+	// Pointer is the JSON pointer of the invalid value in the body, e.g. "/items/0/name".
+	Pointer string `json:"pointer,omitempty"`
+
+	Detail string `json:"detail"`
+}
+
+func (e *ValidationError) Error() string {
+	loc := e.In
+	if e.Name != "" {
+		loc += " " + e.Name
+	}
+	if e.Pointer != "" {
+		loc += " " + e.Pointer
+	}
+	return "openapi: " + loc + ": " + e.Detail
+}
+
+// Problem is an RFC 7807 problem details object, with the violations of the OpenAPI document if any.
+type Problem struct {
+	Type   string             `json:"type"`
+	Title  string             `json:"title"`
+	Status int                `json:"status"`
+	Detail string             `json:"detail,omitempty"`
+	Errors []*ValidationError `json:"errors,omitempty"`
+}
+
+// ValidatorOption configures a Validator.
+type ValidatorOption func(*Validator)
+
+// ValidateResponses validates the responses too, replying 500 with the problem when the response
+// of a handler violates the document. The responses are buffered, so it is meant for the tests.
+func ValidateResponses() ValidatorOption {
+	return func(v *Validator) {
+		v.responses = true
+	}
+}
+
+// Validator validates the requests, and optionally the responses, against an OpenAPI document, e.g.
+//
+//	doc, err := openapi.Load("openapi.json")
+//	...
+//	v, err := openapi.NewValidator(doc)
+//	...
+//	r.Use(v.Handler)
+//
+// The paths of the document are relative to the router the validator is used in, e.g. to the mount of a mounted API.
+// Once a request is routed, e.g. when the validator is a middleware of the route with With(), it is matched
+// to the operation of the path of the route it is served by, with the URL params of the route.
+// Otherwise it is matched with a gor router of the paths of the document, on the routing path of the request,
+// which is its escaped path outside of a gor router. The requests matching no operation are not validated.
+type Validator struct {
+	doc       *Document
+	router    *gor.Mux
+	ops       map[string]*compiledOp // keyed by method and path
+	patterns  map[string]*regexp.Regexp
+	responses bool
+}
+
+// compiledOp is an operation of a document, with its references resolved.
+type compiledOp struct {
+	params    []*Parameter
+	body      *RequestBody
+	responses map[string]*Response
+}
+
+// NewValidator returns a Validator of the document,
+// or an error if its paths are not valid routing patterns or its references cannot be resolved.
+func NewValidator(doc *Document, opts ...ValidatorOption) (*Validator, error) {
+	v := &Validator{doc: doc, router: gor.NewRouter(), ops: make(map[string]*compiledOp), patterns: make(map[string]*regexp.Regexp)}
+	for _, opt := range opts {
+		opt(v)
+	}
+
+	for path, item := range doc.Paths {
+		for method, op := range item {
+			o, err := v.compile(op)
+			if err != nil {
+				return nil, fmt.Errorf("openapi: %s %s: %w", strings.ToUpper(method), path, err)
+			}
+
+			r := v.router.Meta(gor.Meta{operationKey: o}).(*gor.Mux)
+			if err := r.TryMethod(strings.ToUpper(method), path, http.NotFoundHandler()); err != nil {
+				return nil, err
+			}
+			v.ops[strings.ToUpper(method)+" "+pathTemplate(path)] = o
+		}
+	}
+
+	return v, nil
+}
+
+// compile resolves the references of an operation and compiles the patterns of its schemas.
+func (v *Validator) compile(op *Operation) (*compiledOp, error) {
+	o := &compiledOp{responses: make(map[string]*Response)}
+
+	for _, p := range op.Parameters {
+		if p.Ref != "" {
+			ref := p.Ref
+			if p = v.doc.component(ref, "parameters").(*Parameter); p == nil {
+				return nil, fmt.Errorf("unresolved reference %s", ref)
+			}
+		}
+		if err := v.compileSchema(p.Schema, nil); err != nil {
+			return nil, err
+		}
+		o.params = append(o.params, p)
+	}
+
+	if o.body = op.RequestBody; o.body != nil && o.body.Ref != "" {
+		ref := o.body.Ref
+		if o.body = v.doc.component(ref, "requestBodies").(*RequestBody); o.body == nil {
+			return nil, fmt.Errorf("unresolved reference %s", ref)
+		}
+	}
+	if o.body != nil {
+		for _, mt := range o.body.Content {
+			if err := v.compileSchema(mt.Schema, nil); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for status, resp := range op.Responses {
+		if resp.Ref != "" {
+			ref := resp.Ref
+			if resp = v.doc.component(ref, "responses").(*Response); resp == nil {
+				return nil, fmt.Errorf("unresolved reference %s", ref)
+			}
+		}
+		for _, mt := range resp.Content {
+			if err := v.compileSchema(mt.Schema, nil); err != nil {
+				return nil, err
+			}
+		}
+		o.responses[strings.ToUpper(status)] = resp
+	}
+
+	return o, nil
+}
+
+// compileSchema checks the references of a schema and compiles its patterns, visiting each schema once.
+func (v *Validator) compileSchema(s *Schema, seen map[*Schema]bool) error {
+	if s == nil || seen[s] {
+		return nil
+	}
+	if seen == nil {
+		seen = make(map[*Schema]bool)
+	}
+	seen[s] = true
+
+	if s.Ref != "" {
+		ref := v.doc.component(s.Ref, "schemas").(*Schema)
+		if ref == nil {
+			return fmt.Errorf("unresolved reference %s", s.Ref)
+		}
+		return v.compileSchema(ref, seen)
+	}
+
+	if s.Pattern != "" && v.patterns[s.Pattern] == nil {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid schema pattern: %w", err)
+		}
+		v.patterns[s.Pattern] = re
+	}
+
+	subs := []*Schema{s.Items, s.AdditionalProperties, s.Not}
+	subs = append(subs, s.AllOf...)
+	subs = append(subs, s.AnyOf...)
+	subs = append(subs, s.OneOf...)
+	for _, p := range s.Properties {
+		subs = append(subs, p)
+	}
+	for _, sub := range subs {
+		if err := v.compileSchema(sub, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handler is the validation middleware, replying with an RFC 7807 problem to the invalid requests:
+// 400 for the invalid params and bodies, and 415 for the bodies of an undocumented media type.
+func (v *Validator) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		op, pathParam := v.match(r)
+		if op == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if status, errs := v.validateRequest(r, op, pathParam); len(errs) > 0 {
+			writeProblem(w, status, "the request does not conform to the API specification", errs)
+			return
+		}
+
+		if !v.responses {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rec := &responseBuffer{header: make(http.Header)}
+		next.ServeHTTP(rec, r)
+		if rec.status == 0 {
+			rec.status = http.StatusOK
+		}
+
+		if errs := v.validateResponse(op, rec); len(errs) > 0 {
+			writeProblem(w, http.StatusInternalServerError, "the response does not conform to the API specification", errs)
+			return
+		}
+
+		for k, vv := range rec.header {
+			w.Header()[k] = vv
+		}
+		w.WriteHeader(rec.status)
+		w.Write(rec.body.Bytes())
+	})
+}
+
+// match returns the operation matching the request along with the lookup of its path params,
+// or nil if there is none.
+func (v *Validator) match(r *http.Request) (*compiledOp, func(name string) (string, bool)) {
+	rctx := gor.RouteContext(r.Context())
+
+	// the pattern of the route serving the request, unless it is the one of a mount
+	if rctx != nil && len(rctx.RoutePatterns) > 0 {
+		if pattern := rctx.RoutePatterns[len(rctx.RoutePatterns)-1]; !strings.HasSuffix(pattern, "/*") {
+			return v.ops[r.Method+" "+pathTemplate(pattern)], pathParams(r, rctx)
+		}
+	}
+
+	path := r.URL.Path
+	switch {
+	case rctx != nil && rctx.RoutePath != "":
+		path = rctx.RoutePath
+	case r.URL.RawPath != "":
+		path = r.URL.RawPath
+	}
+
+	mctx := gor.NewRouteContext()
+	if !v.router.Match(mctx, r.Method, path) {
+		return nil, nil
+	}
+
+	op, _ := mctx.RouteMeta()[operationKey].(*compiledOp)
+	return op, pathParams(r, mctx)
+}
+
+// pathParams returns the lookup of the URL params of the routing context, unescaped like the path values of net/http.
+func pathParams(r *http.Request, rctx *gor.Context) func(name string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := rctx.URLParamOk(name)
+		if ok && r.URL.RawPath != "" {
+			if v, err := url.PathUnescape(value); err == nil {
+				value = v
+			}
+		}
+		return value, ok
+	}
+}
+
+// pathTemplate returns the OpenAPI path template of a routing pattern,
+// e.g. "/users/{id}" for "/users/{id:int}", and "/files/{path}" for "/files/{path...}".
+func pathTemplate(pattern string) string {
+	var b strings.Builder
+	for {
+		ps := strings.IndexByte(pattern, '{')
+		if ps < 0 {
+			b.WriteString(pattern)
+			return b.String()
+		}
+
+		// the end of the param, skipping the braces of its regexp
+		pe, depth := ps+1, 1
+		for ; pe < len(pattern) && depth > 0; pe++ {
+			switch pattern[pe] {
+			case '{':
+				depth++
+			case '}':
+				depth--
+			}
+		}
+
+		key := pattern[ps+1 : pe-1]
+		if i := strings.IndexByte(key, ':'); i >= 0 {
+			key = key[:i]
+		}
+		key = strings.TrimSuffix(strings.TrimSuffix(key, "..."), "?")
+
+		b.WriteString(pattern[:ps] + "{" + key + "}")
+		pattern = pattern[pe:]
+	}
+}
+
+func (v *Validator) validateRequest(r *http.Request, op *compiledOp, pathParam func(name string) (string, bool)) (int, []*ValidationError) {
+	c := &checker{doc: v.doc, patterns: v.patterns}
+
+	query := r.URL.Query()
+	for _, p := range op.params {
+		c.in, c.name = p.In, p.Name
+
+		var values []string
+		switch p.In {
+		case "path":
+			if value, ok := pathParam(p.Name); ok {
+				values = []string{value}
+			}
+		case "query":
+			values = query[p.Name]
+		case "header":
+			values = r.Header.Values(p.Name)
+		case "cookie":
+			if cookie, err := r.Cookie(p.Name); err == nil {
+				values = []string{cookie.Value}
+			}
+		}
+
+		if len(values) == 0 {
+			if p.Required || p.In == "path" {
+				c.errorf("", "missing required %s param", p.In)
+			}
+			continue
+		}
+		c.check(p.Schema, c.paramValue(p.Schema, p.In, values), "")
+	}
+
+	if op.body == nil {
+		return http.StatusBadRequest, c.errs
+	}
+	c.in, c.name = "body", ""
+
+	body, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		c.errorf("", "cannot read the body: %v", err)
+		return http.StatusBadRequest, c.errs
+	}
+
+	if len(body) == 0 {
+		if op.body.Required {
+			c.errorf("", "missing required body")
+		}
+		return http.StatusBadRequest, c.errs
+	}
+
+	mt, ok := mediaType(op.body.Content, r.Header.Get("Content-Type"))
+	if !ok {
+		c.errorf("", "unsupported content type %q", r.Header.Get("Content-Type"))
+		return http.StatusUnsupportedMediaType, c.errs
+	}
+	c.checkJSON(mt, r.Header.Get("Content-Type"), body)
+
+	return http.StatusBadRequest, c.errs
+}
+
+func (v *Validator) validateResponse(op *compiledOp, rec *responseBuffer) []*ValidationError {
+	c := &checker{doc: v.doc, patterns: v.patterns, in: "response"}
+
+	status := strconv.Itoa(rec.status)
+	resp := op.responses[status]
+	if resp == nil {
+		resp = op.responses[status[:1]+"XX"]
+	}
+	if resp == nil {
+		resp = op.responses["DEFAULT"]
+	}
+	if resp == nil {
+		c.errorf("", "undocumented status %d", rec.status)
+		return c.errs
+	}
+
+	if rec.body.Len() == 0 {
+		return nil
+	}
+	if len(resp.Content) == 0 {
+		c.errorf("", "unexpected body of a %d response", rec.status)
+		return c.errs
+	}
+
+	mt, ok := mediaType(resp.Content, rec.header.Get("Content-Type"))
+	if !ok {
+		c.errorf("", "undocumented content type %q", rec.header.Get("Content-Type"))
+		return c.errs
+	}
+	c.checkJSON(mt, rec.header.Get("Content-Type"), rec.body.Bytes())
+
+	return c.errs
+}
+
+// checkJSON validates a JSON body against the schema of its media type. The other bodies are not validated.
+func (c *checker) checkJSON(mt MediaType, contentType string, body []byte) {
+	base, _, _ := mime.ParseMediaType(contentType)
+	if mt.Schema == nil || (base != "application/json" && !strings.HasSuffix(base, "+json")) {
+		return
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		c.errorf("", "invalid JSON: %v", err)
+		return
+	}
+	if dec.More() {
+		c.errorf("", "invalid JSON: unexpected data after the value")
+		return
+	}
+
+	c.check(mt.Schema, v, "")
+}
+
+// mediaType returns the media type of a content map matching a Content-Type,
+// e.g. "application/json", "application/*" or "*/*".
+func mediaType(content map[string]MediaType, contentType string) (MediaType, bool) {
+	base, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return MediaType{}, false
+	}
+
+	typ, _, _ := strings.Cut(base, "/")
+	for _, key := range []string{base, typ + "/*", "*/*"} {
+		if mt, ok := content[key]; ok {
+			return mt, true
+		}
+	}
+	return MediaType{}, false
+}
+
+// paramValue converts the string values of a param to the JSON value of its schema:
+// the arrays are the repeated query params or the comma-separated values of the other params,
+// and the numbers and booleans which cannot be parsed are left as strings to be reported.
+func (c *checker) paramValue(s *Schema, in string, values []string) interface{} {
+	if s = c.doc.schema(s); s == nil || s.Type != "array" {
+		return scalarParam(s, values[0])
+	}
+
+	if in != "query" || len(values) == 1 {
+		values = strings.Split(strings.Join(values, ","), ",")
+	}
+
+	items := make([]interface{}, len(values))
+	for i, value := range values {
+		items[i] = scalarParam(c.doc.schema(s.Items), value)
+	}
+	return items
+}
+
+func scalarParam(s *Schema, value string) interface{} {
+	if s == nil {
+		return value
+	}
+
+	switch s.Type {
+	case "integer", "number":
+		if _, err := strconv.ParseFloat(value, 64); err == nil {
+			return json.Number(value)
+		}
+	case "boolean":
+		if value == "true" || value == "false" {
+			return value == "true"
+		}
+	}
+	return value
+}
+
+func writeProblem(w http.ResponseWriter, status int, detail string, errs []*ValidationError) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Errors: errs,
+	})
+}
+
+// responseBuffer buffers a response to validate it before it is written.
+type responseBuffer struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *responseBuffer) Header() http.Header {
+	return b.header
+}
+
+func (b *responseBuffer) WriteHeader(status int) {
+	if b.status == 0 {
+		b.status = status
+	}
+}
+
+func (b *responseBuffer) Write(p []byte) (int, error) {
+	if b.status == 0 {
+		b.status = http.StatusOK
+	}
+	return b.body.Write(p)
+}
@@ -0,0 +1,306 @@
+package openapi
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pchchv/gor"
+)
+
+func TestParseYAML(t *testing.T) {
+	if _, err := Parse([]byte("openapi: 3.1.0\npaths: {}\n")); err == nil || !strings.Contains(err.Error(), "YAML") {
+		t.Fatalf("expecting an error for the YAML document, got %v", err)
+	}
+}
+
+func TestLoadYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "openapi.yaml")
+	if err := os.WriteFile(path, []byte("openapi: 3.0.3\ninfo:\n  title: Pets\npaths: {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "YAML documents are not supported") {
+		t.Fatalf("expecting an error for the YAML file, got %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	doc, err := Load("testdata/pets.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if doc.OpenAPI != "3.0.3" || doc.Info.Version != "1.0" || doc.Info.Description != "Pets of the store.\n\nSpec-first.\n" {
+		t.Fatalf("unexpected document %+v", doc)
+	}
+	if d := doc.Paths["/pets"]["post"].Responses["201"].Description; d != "The created pet.\n" {
+		t.Fatalf("unexpected description %q", d)
+	}
+
+	// the path item params are merged into its operations
+	id := doc.Paths["/pets/{id}"]["get"].Parameters[0]
+	if id.Name != "id" || id.Schema.Minimum != nil || *id.Schema.ExclusiveMinimum != 0 {
+		t.Fatalf("unexpected param %+v", id)
+	}
+
+	owner := doc.Components.Schemas["NewPet"].Properties["owner"]
+	if !owner.Nullable || owner.Type != "string" {
+		t.Fatalf("unexpected schema %+v", owner)
+	}
+	if b, _ := json.Marshal(owner); string(b) != `{"format":"email","type":["string","null"]}` {
+		t.Fatalf("unexpected schema JSON %s", b)
+	}
+
+	if _, err := Parse([]byte(`{"openapi": "2.0"}`)); err == nil {
+		t.Fatal("expecting an error for the unsupported version")
+	}
+}
+
+func testValidator(t *testing.T, opts ...ValidatorOption) *Validator {
+	doc, err := Load("testdata/pets.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := NewValidator(doc, opts...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func testProblem(t *testing.T, ts *httptest.Server, method, path, body string, header http.Header) (int, *Problem) {
+	req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.Header.Get("Content-Type") != "application/problem+json" {
+		io.Copy(io.Discard, resp.Body)
+		return resp.StatusCode, nil
+	}
+
+	p := new(Problem)
+	if err := json.NewDecoder(resp.Body).Decode(p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Status != resp.StatusCode || p.Type != "about:blank" || p.Title != http.StatusText(resp.StatusCode) {
+		t.Fatalf("unexpected problem %+v", p)
+	}
+	return resp.StatusCode, p
+}
+
+func problemErrors(p *Problem) string {
+	var errs []string
+	for _, e := range p.Errors {
+		errs = append(errs, e.Error())
+	}
+	return strings.Join(errs, "\n")
+}
+
+func TestValidator(t *testing.T) {
+	v := testValidator(t)
+
+	r := gor.NewRouter()
+	r.Route("/api", func(r gor.Router) {
+		r.Use(v.Handler)
+		r.Get("/pets", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("pets"))
+		})
+		r.Post("/pets", func(w http.ResponseWriter, r *http.Request) {
+			b, _ := io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusCreated)
+			w.Write(b)
+		})
+		r.Get("/pets/{id}", func(w http.ResponseWriter, r *http.Request) {})
+		r.Get("/other", func(w http.ResponseWriter, r *http.Request) {})
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	json := http.Header{"Content-Type": {"application/json"}, "X-Request-Id": {"f47ac10b-58cc-4372-a567-0e02b2c3d479"}}
+
+	tests := []struct {
+		method, path, body string
+		header             http.Header
+		status             int
+		errs               string
+	}{
+		{"GET", "/api/pets?limit=10&tags=cat&tags=dog", "", nil, 200, ""},
+		{"GET", "/api/other?limit=x", "", nil, 200, ""},
+		{"GET", "/api/pets?limit=0&tags=cow", "", nil, 400, "openapi: query limit: expecting a value of at least 1, got 0\nopenapi: query tags /0: value is not one of [\"cat\",\"dog\"]"},
+		{"GET", "/api/pets?limit=ten", "", nil, 400, "openapi: query limit: expecting integer, got string"},
+		{"GET", "/api/pets/1", "", nil, 200, ""},
+		{"GET", "/api/pets/0", "", nil, 400, "openapi: path id: expecting a value greater than 0, got 0"},
+		{"POST", "/api/pets", `{"name": "Tom", "kind": "cat", "owner": null}`, json, 201, ""},
+		{"POST", "/api/pets", `{"name": "Tom", "kind": "cat"}`, http.Header{"Content-Type": {"application/json"}}, 400, "openapi: header X-Request-ID: missing required header param"},
+		{"POST", "/api/pets", ``, json, 400, "openapi: body: missing required body"},
+		{"POST", "/api/pets", `{"name": "tom", "birthday": "2023-13-01", "tags": ["a", "b", 3]}`, json, 400,
+			"openapi: body /kind: missing required property \"kind\"\n" +
+				"openapi: body /birthday: value is not a valid date\n" +
+				"openapi: body /name: value does not match the pattern ^[A-Z]\n" +
+				"openapi: body /tags: expecting at most 2 items, got 3\n" +
+				"openapi: body /tags/2: expecting string, got integer"},
+		{"POST", "/api/pets", `{"name": "Tom"`, json, 400, "openapi: body: invalid JSON: unexpected EOF"},
+		{"POST", "/api/pets", `name=Tom`, http.Header{"Content-Type": {"application/x-www-form-urlencoded"}, "X-Request-Id": json["X-Request-Id"]}, 415, "openapi: body: unsupported content type \"application/x-www-form-urlencoded\""},
+	}
+
+	for i, tt := range tests {
+		status, p := testProblem(t, ts, tt.method, tt.path, tt.body, tt.header)
+		if status != tt.status {
+			t.Fatalf("test %d: expecting status %d, got %d (%+v)", i, tt.status, status, p)
+		}
+		if (p == nil) != (tt.errs == "") || p != nil && problemErrors(p) != tt.errs {
+			t.Fatalf("test %d: expecting the errors\n%s\ngot\n%s", i, tt.errs, problemErrors(p))
+		}
+	}
+}
+
+func TestValidatorRouted(t *testing.T) {
+	v := testValidator(t)
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := gor.NewRouter()
+	r.With(v.Handler).Get("/pets/{id:[0-9a-z]+}", h)
+	r.With(v.Handler).Get("/pets/me", h)
+	r.Route("/escaped", func(r gor.Router) {
+		r.Use(v.Handler)
+		r.Get("/*", h)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		path   string
+		status int
+		errs   string
+	}{
+		// the operation of the route serving the request, with its URL params
+		{"/pets/1", 200, ""},
+		{"/pets/x", 400, "openapi: path id: expecting integer, got string"},
+		{"/pets/me", 200, ""},
+		// the escaped routing path
+		{"/escaped/pets/a%2Fb", 400, "openapi: path id: expecting integer, got string"},
+	}
+
+	for _, tt := range tests {
+		status, p := testProblem(t, ts, "GET", tt.path, "", nil)
+		if status != tt.status || (p == nil) != (tt.errs == "") || p != nil && problemErrors(p) != tt.errs {
+			t.Fatalf("%s: expecting %d %q, got %d %+v", tt.path, tt.status, tt.errs, status, p)
+		}
+	}
+
+	// outside of a gor router, the escaped path of the request
+	w := httptest.NewRecorder()
+	v.Handler(http.HandlerFunc(h)).ServeHTTP(w, httptest.NewRequest("GET", "/pets/a%2Fb", nil))
+	if w.Code != 400 || !strings.Contains(w.Body.String(), "expecting integer, got string") {
+		t.Fatalf("expecting the escaped path to be validated, got %d %s", w.Code, w.Body.String())
+	}
+
+	if got := pathTemplate("/users/{id:int}/{path...}/{re:[0-9]{2}}/{opt?}"); got != "/users/{id}/{path}/{re}/{opt}" {
+		t.Fatalf("unexpected path template %q", got)
+	}
+}
+
+func TestValidateResponses(t *testing.T) {
+	v := testValidator(t, ValidateResponses())
+
+	r := gor.NewRouter()
+	r.Use(v.Handler)
+	r.Get("/pets", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"id": 1, "name": "Tom", "kind": "cat"}, {"name": "Rex", "kind": "dog"}]`))
+	})
+	r.Get("/pets/{id}", func(w http.ResponseWriter, r *http.Request) {
+		if gor.URLParam(r, "id") == "1" {
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("X-Pet", "Tom")
+			w.Write([]byte(`{"id": 1, "name": "Tom", "kind": "cat"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	status, p := testProblem(t, ts, "GET", "/pets", "", nil)
+	if status != 500 || problemErrors(p) != "openapi: response /1/id: missing required property \"id\"" {
+		t.Fatalf("unexpected response %d %+v", status, p)
+	}
+
+	status, p = testProblem(t, ts, "GET", "/pets/2", "", nil)
+	if status != 500 || problemErrors(p) != "openapi: response: undocumented status 404" {
+		t.Fatalf("unexpected response %d %+v", status, p)
+	}
+
+	resp, body := testRequest(t, ts, "GET", "/pets/1", nil)
+	if resp.StatusCode != 200 || resp.Header.Get("X-Pet") != "Tom" || body != `{"id": 1, "name": "Tom", "kind": "cat"}` {
+		t.Fatalf("unexpected response %d %q", resp.StatusCode, body)
+	}
+}
+
+func TestNewValidatorErrors(t *testing.T) {
+	doc, err := Parse([]byte(`{"openapi": "3.1.0", "paths": {"/a": {"get": {"responses": {"200": {"$ref": "#/components/responses/Missing"}}}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewValidator(doc); err == nil || err.Error() != "openapi: GET /a: unresolved reference #/components/responses/Missing" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	doc, err = Parse([]byte(`{"openapi": "3.1.0", "paths": {"/a": {"post": {"requestBody": {"content": {"application/json": {"schema": {"type": "string", "pattern": "("}}}}}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewValidator(doc); err == nil || !strings.Contains(err.Error(), "invalid schema pattern") {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	// the generated documents are valid
+	generated, err := testDocument(t).JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc, err = Parse(generated); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewValidator(doc); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func testRequest(t *testing.T, ts *httptest.Server, method, path string, body io.Reader) (*http.Response, string) {
+	req, err := http.NewRequest(method, ts.URL+path, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, string(b)
+}
@@ -0,0 +1,93 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// plainScalar matches the strings which can be written as plain YAML scalars.
+var plainScalar = regexp.MustCompile(`^[A-Za-z_/.][A-Za-z0-9_ ./{}$-]*$`)
+
+// jsonToYAML converts a JSON document to YAML, with the keys of the objects in order.
+func jsonToYAML(b []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	writeYAML(&out, v, 0)
+	return out.Bytes(), nil
+}
+
+func writeYAML(b *bytes.Buffer, v interface{}, indent int) {
+	pad := strings.Repeat("  ", indent)
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, k := range sortedKeys(v) {
+			b.WriteString(pad + yamlString(k) + ":")
+			writeYAMLValue(b, v[k], indent)
+		}
+
+	case []interface{}:
+		for _, item := range v {
+			b.WriteString(pad + "-")
+			writeYAMLValue(b, item, indent)
+		}
+	}
+}
+
+// writeYAMLValue writes a value following a key or a list item marker.
+func writeYAMLValue(b *bytes.Buffer, v interface{}, indent int) {
+	switch c := v.(type) {
+	case map[string]interface{}:
+		if len(c) == 0 {
+			b.WriteString(" {}\n")
+			return
+		}
+	case []interface{}:
+		if len(c) == 0 {
+			b.WriteString(" []\n")
+			return
+		}
+	default:
+		b.WriteString(" " + yamlScalar(v) + "\n")
+		return
+	}
+
+	b.WriteString("\n")
+	writeYAML(b, v, indent+1)
+}
+
+func yamlScalar(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	case string:
+		return yamlString(v)
+	}
+	return "null"
+}
+
+// yamlString returns the string as a plain scalar if it is not ambiguous, or else quoted.
+func yamlString(s string) string {
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~":
+		return strconv.Quote(s)
+	}
+	if plainScalar.MatchString(s) && !strings.HasSuffix(s, " ") {
+		return s
+	}
+	return strconv.Quote(s)
+}
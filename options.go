@@ -0,0 +1,171 @@
+package gor
+
+import (
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// Option configures the routing behavior of a Mux, see NewMux.
+type Option func(cfg *config)
+
+// TrailingSlash is the policy of a Mux for request paths matching a route only once
+// their trailing slash is added or removed.
+type TrailingSlash uint8
+
+const (
+	// TrailingSlashStrict requires the request paths to match the routing patterns exactly.
+	TrailingSlashStrict TrailingSlash = iota
+
+	// TrailingSlashStrip routes a request path with a trailing slash matching no route
+	// as if it had no trailing slash.
+	TrailingSlashStrip
+
+	// TrailingSlashRedirect permanently redirects a request path matching no route to
+	// the same URL with the trailing slash removed or added, when that path matches a route.
+	// The query string and the path prefix of the mounts are preserved.
+	TrailingSlashRedirect
+)
+
+// config holds the routing options of a Mux.
+type config struct {
+	// policy for paths with a mismatching trailing slash
+	trailingSlash TrailingSlash
+
+	// answer OPTIONS requests for routes without an OPTIONS handler
+	autoOptions bool
+
+	// match the static path segments case-insensitively
+	caseInsensitive bool
+
+	// clean the routing path before the route lookup
+	cleanPath bool
+
+	// change the routes on a copy of the routing tree, swapped atomically
+	dynamic bool
+
+	// response header to trace the route lookup in
+	traceHeader string
+}
+
+// WithTrailingSlash sets the policy of the Mux for request paths matching a route only once
+// their trailing slash is added or removed. The default policy is TrailingSlashStrict.
+func WithTrailingSlash(policy TrailingSlash) Option {
+	return func(cfg *config) {
+		cfg.trailingSlash = policy
+	}
+}
+
+// WithCaseInsensitive makes the Mux match the static segments of the routing patterns
+// regardless of the ASCII case of the request path. Param values keep their original case.
+func WithCaseInsensitive() Option {
+	return func(cfg *config) {
+		cfg.caseInsensitive = true
+	}
+}
+
+// WithCleanPath makes the Mux route the requests by their cleaned path,
+// collapsing repeated slashes and resolving "." and ".." elements, e.g. "/users//1/../2" is routed as "/users/2".
+// The trailing slash of the path is kept.
+func WithCleanPath() Option {
+	return func(cfg *config) {
+		cfg.cleanPath = true
+	}
+}
+
+// WithAutoOptions makes the Mux answer OPTIONS requests to routes without an OPTIONS handler
+// with a 204 No Content response, listing the methods supported by the route in the Allow header.
+func WithAutoOptions() Option {
+	return func(cfg *config) {
+		cfg.autoOptions = true
+	}
+}
+
+// WithDynamicRoutes allows the routes of the Mux to be added, removed with RemoveRoute
+// and unmounted with Unmount while it is serving requests.
+// Each change is made on a copy of the routing tree, which then atomically replaces it,
+// so that the requests being routed keep seeing a consistent tree without locking it.
+// The middlewares and the first route of the Mux must still be set up before serving requests.
+// Without this option, the routing tree and its index of the static routes, a map read by every request,
+// are changed in place, so that changing the routes while serving requests crashes the program.
+func WithDynamicRoutes() Option {
+	return func(cfg *config) {
+		cfg.dynamic = true
+	}
+}
+
+// WithTraceHeader makes the Mux trace the route lookup of each request in the response header `name`,
+// e.g. "X-Route-Trace", with a compact form of the steps reported by Mux.Explain.
+// It is meant for debugging, as the trace exposes the routes and costs some allocations per request.
+func WithTraceHeader(name string) Option {
+	return func(cfg *config) {
+		cfg.traceHeader = name
+	}
+}
+
+// autoOptionsHandler responds to an OPTIONS request with a 204 and the methods allowed for the route.
+func autoOptionsHandler(w http.ResponseWriter, r *http.Request) {
+	if rctx := RouteContext(r.Context()); rctx != nil {
+		w.Header().Set("Allow", strings.Join(rctx.AllowedMethods(), ", "))
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// cleanPath returns the canonical form of a routing path,
+// keeping its trailing slash. Clean paths are returned as is.
+func cleanPath(p string) string {
+	if p == "" {
+		return "/"
+	}
+
+	if p[0] != '/' {
+		p = "/" + p
+	}
+
+	np := path.Clean(p)
+	if p[len(p)-1] == '/' && np != "/" {
+		if len(np)+1 == len(p) && strings.HasPrefix(p, np) {
+			return p
+		}
+		np += "/"
+	}
+
+	return np
+}
+
+// redirectRoutePath redirects the request to its URL with the routing path `from` replaced by `to`,
+// keeping the path prefix of the mounts and the query string.
+func redirectRoutePath(w http.ResponseWriter, r *http.Request, from, to string) {
+	p := r.URL.Path
+	escaped := r.URL.RawPath != ""
+	if escaped {
+		p = r.URL.RawPath
+	}
+
+	if strings.HasSuffix(p, from) {
+		p = p[:len(p)-len(from)] + to
+	} else if strings.HasSuffix(p, "/") {
+		p = p[:len(p)-1]
+	} else {
+		p += "/"
+	}
+
+	if !escaped {
+		p = (&url.URL{Path: p}).EscapedPath()
+	}
+
+	// avoid redirecting to a scheme-relative URL, e.g. "//example.com"
+	p = "/" + strings.TrimLeft(p, "/")
+
+	if r.URL.RawQuery != "" {
+		p += "?" + r.URL.RawQuery
+	}
+
+	code := http.StatusPermanentRedirect
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		code = http.StatusMovedPermanently
+	}
+
+	http.Redirect(w, r, p, code)
+}
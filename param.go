@@ -0,0 +1,300 @@
+package gor
+
+import (
+	"encoding"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/exp/constraints"
+)
+
+// ErrMissingParam is the error of a ParamError for a URL param absent from the request.
+var ErrMissingParam = errors.New("missing param")
+
+// ParamError describes a URL param which cannot be converted to the requested type.
+type ParamError struct {
+	// Key of the URL param.
+	Key string
+
+	// Value of the URL param.
+	Value string
+
+	// Type the value was converted to.
+	Type string
+
+	// Err is the conversion error, or ErrMissingParam.
+	Err error
+}
+
+func (e *ParamError) Error() string {
+	if errors.Is(e.Err, ErrMissingParam) {
+		return fmt.Sprintf("gor: missing param '%s'", e.Key)
+	}
+	return fmt.Sprintf("gor: invalid value '%s' for param '%s', expecting %s", e.Value, e.Key, e.Type)
+}
+
+func (e *ParamError) Unwrap() error {
+	return e.Err
+}
+
+// ParamErrors are the errors of the params of a struct bound by BindParams.
+type ParamErrors []*ParamError
+
+func (e ParamErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = strings.TrimPrefix(err.Error(), "gor: ")
+	}
+	return "gor: " + strings.Join(msgs, "; ")
+}
+
+func (e ParamErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// UUID is a UUID URL param, see Param.
+type UUID [16]byte
+
+// ParseUUID parses a UUID in the canonical 8-4-4-4-12 hex form.
+func ParseUUID(s string) (UUID, error) {
+	var u UUID
+	if !isUUID(s) {
+		return u, fmt.Errorf("gor: invalid UUID '%s'", s)
+	}
+
+	s = strings.ReplaceAll(s, "-", "")
+	if _, err := hex.Decode(u[:], []byte(s)); err != nil {
+		return u, err
+	}
+	return u, nil
+}
+
+// String returns the UUID in the canonical lowercase 8-4-4-4-12 hex form.
+func (u UUID) String() string {
+	b := make([]byte, 36)
+	hex.Encode(b[0:8], u[0:4])
+	b[8] = '-'
+	hex.Encode(b[9:13], u[4:6])
+	b[13] = '-'
+	hex.Encode(b[14:18], u[6:8])
+	b[18] = '-'
+	hex.Encode(b[19:23], u[8:10])
+	b[23] = '-'
+	hex.Encode(b[24:], u[10:])
+	return string(b)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (u *UUID) UnmarshalText(text []byte) error {
+	v, err := ParseUUID(string(text))
+	if err != nil {
+		return err
+	}
+	*u = v
+	return nil
+}
+
+// Param returns the URL param `key` of the request converted to T, e.g.
+//
+//	id, err := gor.Param[int64](r, "id")
+//
+// T can be a string, a bool, an integer or a float type, a UUID, a time.Time, in the RFC 3339 or
+// the YYYY-MM-DD form, a time.Duration, or any type whose pointer implements encoding.TextUnmarshaler.
+// The error is a *ParamError, wrapping ErrMissingParam when the param is absent from the request.
+func Param[T any](r *http.Request, key string) (T, error) {
+	var v T
+
+	value, ok := URLParamOk(r, key)
+	if !ok {
+		return v, &ParamError{Key: key, Type: paramTypeName(&v), Err: ErrMissingParam}
+	}
+
+	if err := parseParam(&v, value); err != nil {
+		return v, &ParamError{Key: key, Value: value, Type: paramTypeName(&v), Err: err}
+	}
+
+	return v, nil
+}
+
+// BindParams sets the fields of the struct pointed to by `dst` tagged with `param:"key"`
+// to the URL params of the request, converted as by Param. Pointer fields are allocated for the present params.
+// Fields tagged with `param:"key,optional"` are left unset when their param is absent, the other ones are required.
+// The error is ParamErrors, describing each of the params which are absent or cannot be converted.
+func BindParams(r *http.Request, dst interface{}) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("gor: BindParams expects a pointer to a struct, got %T", dst)
+	}
+	rv = rv.Elem()
+	rt := rv.Type()
+
+	var errs ParamErrors
+	for i := 0; i < rt.NumField(); i++ {
+		f := rt.Field(i)
+		tag, ok := f.Tag.Lookup("param")
+		if !ok || tag == "-" || !f.IsExported() {
+			continue
+		}
+
+		key, opts, _ := strings.Cut(tag, ",")
+		if key == "" {
+			key = f.Name
+		}
+
+		fv := rv.Field(i)
+		value, ok := URLParamOk(r, key)
+		if !ok {
+			if opts != "optional" {
+				errs = append(errs, &ParamError{Key: key, Type: paramTypeName(fv.Addr().Interface()), Err: ErrMissingParam})
+			}
+			continue
+		}
+
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+			fv = fv.Elem()
+		}
+
+		if err := parseParam(fv.Addr().Interface(), value); err != nil {
+			errs = append(errs, &ParamError{Key: key, Value: value, Type: paramTypeName(fv.Addr().Interface()), Err: err})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// parseParam converts the param `value` into the value pointed to by `dst`.
+func parseParam(dst interface{}, value string) (err error) {
+	switch p := dst.(type) {
+	case *string:
+		*p = value
+	case *bool:
+		*p, err = strconv.ParseBool(value)
+	case *int:
+		*p, err = parseSigned[int](value, strconv.IntSize)
+	case *int8:
+		*p, err = parseSigned[int8](value, 8)
+	case *int16:
+		*p, err = parseSigned[int16](value, 16)
+	case *int32:
+		*p, err = parseSigned[int32](value, 32)
+	case *int64:
+		*p, err = parseSigned[int64](value, 64)
+	case *uint:
+		*p, err = parseUnsigned[uint](value, strconv.IntSize)
+	case *uint8:
+		*p, err = parseUnsigned[uint8](value, 8)
+	case *uint16:
+		*p, err = parseUnsigned[uint16](value, 16)
+	case *uint32:
+		*p, err = parseUnsigned[uint32](value, 32)
+	case *uint64:
+		*p, err = parseUnsigned[uint64](value, 64)
+	case *float32:
+		*p, err = parseFloat[float32](value, 32)
+	case *float64:
+		*p, err = parseFloat[float64](value, 64)
+	case *time.Duration:
+		*p, err = time.ParseDuration(value)
+	case *time.Time:
+		*p, err = parseTime(value)
+	case encoding.TextUnmarshaler:
+		err = p.UnmarshalText([]byte(value))
+	default:
+		err = parseParamKind(reflect.ValueOf(dst).Elem(), value)
+	}
+
+	return err
+}
+
+// parseParamKind converts the param `value` into a value of a named basic type, e.g. `type UserID int64`.
+func parseParamKind(v reflect.Value, value string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := parseSigned[int64](value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := parseUnsigned[uint64](value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := parseFloat[float64](value, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported param type %s", v.Type())
+	}
+
+	return nil
+}
+
+func parseSigned[T constraints.Signed](value string, bitSize int) (T, error) {
+	n, err := strconv.ParseInt(value, 10, bitSize)
+	return T(n), err
+}
+
+func parseUnsigned[T constraints.Unsigned](value string, bitSize int) (T, error) {
+	n, err := strconv.ParseUint(value, 10, bitSize)
+	return T(n), err
+}
+
+func parseFloat[T constraints.Float](value string, bitSize int) (T, error) {
+	n, err := strconv.ParseFloat(value, bitSize)
+	return T(n), err
+}
+
+// parseTime parses a time in the RFC 3339 or the YYYY-MM-DD form.
+func parseTime(value string) (time.Time, error) {
+	if isDate(value) {
+		return time.Parse(time.DateOnly, value)
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// paramTypeName names the type of the value pointed to by `dst` in the param errors.
+func paramTypeName(dst interface{}) string {
+	switch dst.(type) {
+	case *UUID:
+		return "UUID"
+	case *time.Time:
+		return "time"
+	case *time.Duration:
+		return "duration"
+	}
+	return reflect.TypeOf(dst).Elem().String()
+}
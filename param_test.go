@@ -0,0 +1,136 @@
+package gor
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParam(t *testing.T) {
+	r := NewRouter()
+	r.Get("/users/{id}/posts/{uuid}/{ts}/{ttl}/{ratio}/{ok}", func(w http.ResponseWriter, r *http.Request) {
+		id, err := Param[int64](r, "id")
+		if err != nil || id != 42 {
+			t.Fatalf("id: expecting 42, got %v %v", id, err)
+		}
+
+		u, err := Param[UUID](r, "uuid")
+		if err != nil || u.String() != "0b0f0e6e-6f1c-4b7a-9d0e-2a2f4c1b3e5d" {
+			t.Fatalf("uuid: got %v %v", u, err)
+		}
+
+		ts, err := Param[time.Time](r, "ts")
+		if err != nil || !ts.Equal(time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)) {
+			t.Fatalf("ts: got %v %v", ts, err)
+		}
+
+		ttl, err := Param[time.Duration](r, "ttl")
+		if err != nil || ttl != 90*time.Second {
+			t.Fatalf("ttl: got %v %v", ttl, err)
+		}
+
+		ratio, err := Param[float32](r, "ratio")
+		if err != nil || ratio != 0.5 {
+			t.Fatalf("ratio: got %v %v", ratio, err)
+		}
+
+		ok, err := Param[bool](r, "ok")
+		if err != nil || !ok {
+			t.Fatalf("ok: got %v %v", ok, err)
+		}
+
+		_, err = Param[uint8](r, "id")
+		if err != nil {
+			t.Fatalf("id: expecting uint8, got %v", err)
+		}
+
+		var perr *ParamError
+		if _, err = Param[int](r, "ttl"); !errors.As(err, &perr) || perr.Key != "ttl" || perr.Type != "int" {
+			t.Fatalf("ttl: expecting a param error, got %v", err)
+		}
+
+		if _, err = Param[int](r, "page"); !errors.Is(err, ErrMissingParam) {
+			t.Fatalf("page: expecting a missing param error, got %v", err)
+		}
+
+		w.Write([]byte("ok"))
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	if _, body := testRequest(t, ts, "GET", "/users/42/posts/0B0F0E6E-6F1C-4B7A-9D0E-2A2F4C1B3E5D/2023-03-01/90s/0.5/true", nil); body != "ok" {
+		t.Fatalf(body)
+	}
+}
+
+func TestBindParams(t *testing.T) {
+	type userID int64
+
+	type params struct {
+		ID     userID  `param:"id"`
+		Org    string  `param:"org"`
+		Page   *int    `param:"page,optional"`
+		Limit  uint16  `param:"limit,optional"`
+		Ignore float64 `param:"-"`
+		Other  string
+	}
+
+	r := NewRouter()
+	r.Get("/orgs/{org}/users/{id}/{page?}", func(w http.ResponseWriter, r *http.Request) {
+		var p params
+		if err := BindParams(r, &p); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		page := 0
+		if p.Page != nil {
+			page = *p.Page
+		}
+		w.Write([]byte(p.Org + "/" + URLParam(r, "id") + "/" + strings.Repeat("p", page)))
+	})
+	r.Get("/bad/{org}/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var p struct {
+			ID    int  `param:"id"`
+			Flag  bool `param:"org"`
+			Count int  `param:"count"`
+		}
+
+		err := BindParams(r, &p)
+
+		var perrs ParamErrors
+		if !errors.As(err, &perrs) || len(perrs) != 3 || !errors.Is(err, ErrMissingParam) {
+			t.Fatalf("expecting 3 param errors, got %v", err)
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	if _, body := testRequest(t, ts, "GET", "/orgs/acme/users/7", nil); body != "acme/7/" {
+		t.Fatalf(body)
+	}
+	if _, body := testRequest(t, ts, "GET", "/orgs/acme/users/7/2", nil); body != "acme/7/pp" {
+		t.Fatalf(body)
+	}
+
+	resp, body := testRequest(t, ts, "GET", "/orgs/acme/users/x", nil)
+	if resp.StatusCode != 400 || body != "gor: invalid value 'x' for param 'id', expecting gor.userID\n" {
+		t.Fatalf("%d %q", resp.StatusCode, body)
+	}
+
+	_, body = testRequest(t, ts, "GET", "/bad/yes/1.5", nil)
+	expected := "gor: invalid value '1.5' for param 'id', expecting int; invalid value 'yes' for param 'org', expecting bool; missing param 'count'\n"
+	if body != expected {
+		t.Fatalf("expecting %q, got %q", expected, body)
+	}
+
+	if err := BindParams(httptest.NewRequest("GET", "/", nil), params{}); err == nil {
+		t.Fatal("expecting an error for a non-pointer destination")
+	}
+}
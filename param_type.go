@@ -0,0 +1,174 @@
+package gor
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sync"
+)
+
+// paramTypes is the registry of named param types usable as `{param:type}` in routing patterns.
+var paramTypes = struct {
+	m map[string]func(string) bool
+	sync.RWMutex
+}{
+	m: map[string]func(string) bool{
+		"int":   isInt,
+		"uint":  isUint,
+		"uuid":  isUUID,
+		"alpha": isAlpha,
+		"date":  isDate,
+	},
+}
+
+// RegisterParamType adds a named param type, which can be used in routing patterns as `{param:name}`.
+// The `match` func reports whether a param value is valid for the type and
+// is called instead of a regexp for every request routed through the param.
+// Param types must be registered before the routes using them.
+func RegisterParamType(name string, match func(string) bool) {
+	if match == nil {
+		panic(fmt.Sprintf("gor: attempting to RegisterParamType() a nil matcher for '%s'", name))
+	}
+
+	if name == "" {
+		panic("gor: param type name must not be empty")
+	}
+
+	for i := 0; i < len(name); i++ {
+		if !isAlnum(name[i]) && name[i] != '_' {
+			panic(fmt.Sprintf("gor: param type name '%s' must contain only letters, digits and '_'", name))
+		}
+	}
+
+	paramTypes.Lock()
+	paramTypes.m[name] = match
+	paramTypes.Unlock()
+}
+
+// lookupParamType returns the matcher of a registered param type.
+func lookupParamType(name string) (func(string) bool, bool) {
+	paramTypes.RLock()
+	match, ok := paramTypes.m[name]
+	paramTypes.RUnlock()
+	return match, ok
+}
+
+// matchParamValue reports whether `value` is valid for a param type name or a regexp pattern.
+func matchParamValue(rexpat, value string) bool {
+	if match, ok := lookupParamType(rexpat); ok {
+		return match(value)
+	}
+
+	rex, err := regexp.Compile(rexpat)
+	if err != nil {
+		return false
+	}
+
+	return rex.MatchString(value)
+}
+
+// isInt matches base 10 integers fitting into an int64.
+func isInt(s string) bool {
+	if s != "" && s[0] == '-' {
+		return isUintMax(s[1:], math.MaxInt64+1)
+	}
+	return isUintMax(s, math.MaxInt64)
+}
+
+// isUint matches base 10 unsigned integers fitting into an uint64.
+func isUint(s string) bool {
+	return isUintMax(s, math.MaxUint64)
+}
+
+func isUintMax(s string, max uint64) bool {
+	if s == "" {
+		return false
+	}
+
+	var n uint64
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+
+		d := uint64(s[i] - '0')
+		if n > (max-d)/10 {
+			return false
+		}
+		n = n*10 + d
+	}
+
+	return true
+}
+
+// isUUID matches UUIDs in the canonical 8-4-4-4-12 hex form.
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		switch i {
+		case 8, 13, 18, 23:
+			if s[i] != '-' {
+				return false
+			}
+		default:
+			if !isHex(s[i]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// isAlpha matches non-empty strings of ASCII letters.
+func isAlpha(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		if c := s[i] | 0x20; c < 'a' || c > 'z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isDate matches valid calendar dates in the YYYY-MM-DD form.
+func isDate(s string) bool {
+	if len(s) != 10 || s[4] != '-' || s[7] != '-' {
+		return false
+	}
+
+	for _, i := range [...]int{0, 1, 2, 3, 5, 6, 8, 9} {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+
+	year := int(s[0]-'0')*1000 + int(s[1]-'0')*100 + int(s[2]-'0')*10 + int(s[3]-'0')
+	month := int(s[5]-'0')*10 + int(s[6]-'0')
+	day := int(s[8]-'0')*10 + int(s[9]-'0')
+	if month < 1 || month > 12 || day < 1 {
+		return false
+	}
+
+	days := [...]int{31, 28, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}[month-1]
+	if month == 2 && year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+		days = 29
+	}
+
+	return day <= days
+}
+
+func isHex(c byte) bool {
+	return (c >= '0' && c <= '9') || (c|0x20 >= 'a' && c|0x20 <= 'f')
+}
+
+func isAlnum(c byte) bool {
+	return (c >= '0' && c <= '9') || (c|0x20 >= 'a' && c|0x20 <= 'z')
+}
@@ -0,0 +1,109 @@
+package gor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParamTypeMatchers(t *testing.T) {
+	tests := []struct {
+		match func(string) bool
+		valid []string
+		wrong []string
+	}{
+		{isInt, []string{"0", "42", "-7", "9223372036854775807", "-9223372036854775808"},
+			[]string{"", "-", "4a", "1.5", "9223372036854775808", "-9223372036854775809"}},
+		{isUint, []string{"0", "18446744073709551615"},
+			[]string{"", "-1", "18446744073709551616", "x"}},
+		{isUUID, []string{"123e4567-e89b-12d3-a456-426614174000", "123E4567-E89B-12D3-A456-426614174000"},
+			[]string{"", "123e4567e89b12d3a456426614174000", "123e4567-e89b-12d3-a456-42661417400g"}},
+		{isAlpha, []string{"abc", "XyZ"},
+			[]string{"", "ab1", "a-b", "a@"}},
+		{isDate, []string{"2024-02-29", "2023-12-31", "2000-02-29"},
+			[]string{"", "2023-02-29", "1900-02-29", "2023-13-01", "2023-00-10", "2023-1-01", "2023/01/01"}},
+	}
+
+	for _, tt := range tests {
+		for _, v := range tt.valid {
+			if !tt.match(v) {
+				t.Errorf("expecting '%s' to be valid", v)
+			}
+		}
+		for _, v := range tt.wrong {
+			if tt.match(v) {
+				t.Errorf("expecting '%s' to be invalid", v)
+			}
+		}
+	}
+}
+
+func TestMuxParamTypes(t *testing.T) {
+	RegisterParamType("hexcolor", func(s string) bool {
+		if len(s) != 6 {
+			return false
+		}
+		for i := 0; i < len(s); i++ {
+			if !isHex(s[i]) {
+				return false
+			}
+		}
+		return true
+	})
+
+	h := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			rctx := RouteContext(r.Context())
+			w.Write([]byte(name + ":" + strings.Join(rctx.URLParams.Values, ",")))
+		}
+	}
+
+	r := NewRouter()
+	r.Get("/items/{id:int}", h("int"))
+	r.Get("/items/{uuid:uuid}", h("uuid"))
+	r.Get("/items/{slug:alpha}", h("alpha"))
+	r.Get("/items/{slug}", h("param"))
+	r.Get("/reports/{d:date}/{n:uint}", h("report"))
+	r.Get("/colors/{c:hexcolor}", h("color"))
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	tests := []struct {
+		path string
+		body string
+	}{
+		{"/items/-12", "int:-12"},
+		{"/items/123e4567-e89b-12d3-a456-426614174000", "uuid:123e4567-e89b-12d3-a456-426614174000"},
+		{"/items/hello", "alpha:hello"},
+		{"/items/hello-1", "param:hello-1"},
+		{"/reports/2024-02-29/3", "report:2024-02-29,3"},
+		{"/reports/2023-02-29/3", "404 page not found\n"},
+		{"/reports/2024-02-29/-3", "404 page not found\n"},
+		{"/colors/ff00aa", "color:ff00aa"},
+		{"/colors/ff00a", "404 page not found\n"},
+	}
+
+	for _, tt := range tests {
+		if _, body := testRequest(t, ts, "GET", tt.path, nil); body != tt.body {
+			t.Fatalf("%s: expecting '%s', got '%s'", tt.path, tt.body, body)
+		}
+	}
+
+	var patterns []string
+	Walk(r, func(method, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		patterns = append(patterns, route)
+		return nil
+	})
+
+	for _, p := range []string{"/items/{id:int}", "/reports/{d:date}/{n:uint}", "/colors/{c:hexcolor}"} {
+		found := false
+		for _, wp := range patterns {
+			found = found || wp == p
+		}
+		if !found {
+			t.Fatalf("expecting Walk to report '%s', got %v", p, patterns)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+//go:build go1.22
+
+package gor
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// setPathValues sets the URL params as the path values of the request, for the handlers using http.Request.PathValue.
+// The values are unescaped, like the ones set by net/http.ServeMux. The wildcards of the mounts are skipped.
+func setPathValues(r *http.Request, rctx *Context) {
+	for i, key := range rctx.URLParams.Keys {
+		if key == "*" {
+			continue
+		}
+		value := rctx.URLParams.Values[i]
+		if r.URL.RawPath != "" {
+			if v, err := url.PathUnescape(value); err == nil {
+				value = v
+			}
+		}
+		r.SetPathValue(key, value)
+	}
+}
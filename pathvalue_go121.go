@@ -0,0 +1,8 @@
+//go:build !go1.22
+
+package gor
+
+import "net/http"
+
+// setPathValues is a no-op before Go 1.22, which introduced http.Request.PathValue.
+func setPathValues(r *http.Request, rctx *Context) {}
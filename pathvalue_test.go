@@ -0,0 +1,45 @@
+//go:build go1.22
+
+package gor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPathValue(t *testing.T) {
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.PathValue("owner") + " " + r.PathValue("path") + " " + r.PathValue("*")))
+	})
+
+	r := NewRouter()
+	r.HandleStd("GET /repos/{owner}/files/{path...}", h)
+	r.Route("/users/{owner}", func(r Router) {
+		r.HandleStd("GET /{path}", h)
+		r.Get("/{path}/plain", h)
+	})
+	r.Get("/plain/{owner}/*", h)
+
+	sub := NewRouter()
+	sub.Get("/{path}", h)
+	r.Mount("/mounted/{owner}", sub)
+
+	for _, tt := range []struct {
+		path string
+		body string
+	}{
+		{"/repos/ann/files/a/b%2Fc.txt", "ann a/b/c.txt "},
+		{"/users/bob/x%20y", "bob x y "},
+		{"/users/bob/x/plain", "bob x "},
+		// the wildcards of the routes and of the mounts are not path values
+		{"/plain/bob/x", "bob  "},
+		{"/mounted/bob/x", "bob x "},
+	} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", tt.path, nil))
+		if w.Body.String() != tt.body {
+			t.Fatalf("%s: expecting %q, got %q", tt.path, tt.body, w.Body.String())
+		}
+	}
+}
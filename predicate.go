@@ -0,0 +1,199 @@
+package gor
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// Predicate is a condition on a request, beyond its method and path, that the routes registered with Where require.
+// A route of a method and path can be registered with several sets of predicates. The requests are served
+// by the first route, in registration order, whose predicates all match, or else by the route registered
+// without predicates, if any, or else by the NotFound handler, regardless of the registration order, e.g.
+//
+//	r.Where(gor.Query("format", "csv")).Get("/reports", reportsCSV)
+//	r.Where(gor.Header("X-API-Version", "2")).Get("/reports", reportsV2)
+//	r.Get("/reports", reports)
+//
+// Registering the route without predicates again replaces the fallback only.
+// The predicates are evaluated once the route is found in the routing tree, so Match() does not consider them.
+type Predicate interface {
+	// Match reports whether the request matches the predicate.
+	Match(r *http.Request) bool
+
+	// String describes the predicate, e.g. "header X-API-Version=2".
+	String() string
+}
+
+type predicateFunc struct {
+	desc string
+	fn   func(r *http.Request) bool
+}
+
+func (p *predicateFunc) Match(r *http.Request) bool { return p.fn(r) }
+func (p *predicateFunc) String() string             { return p.desc }
+
+// PredicateFunc returns a Predicate matching the requests for which fn returns true, described by `desc`.
+func PredicateFunc(desc string, fn func(r *http.Request) bool) Predicate {
+	return &predicateFunc{desc: desc, fn: fn}
+}
+
+// Header returns a Predicate matching the requests with the header `name` set to `value`,
+// or with the header `name` set to any value if `value` is empty.
+func Header(name, value string) Predicate {
+	name = http.CanonicalHeaderKey(name)
+	if value == "" {
+		return PredicateFunc("header "+name, func(r *http.Request) bool {
+			return len(r.Header.Values(name)) > 0
+		})
+	}
+
+	return PredicateFunc("header "+name+"="+value, func(r *http.Request) bool {
+		for _, v := range r.Header.Values(name) {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Query returns a Predicate matching the requests with the query parameter `name` set to `value`,
+// or with the query parameter `name` set to any value if `value` is empty.
+func Query(name, value string) Predicate {
+	if value == "" {
+		return PredicateFunc("query "+name, func(r *http.Request) bool {
+			return r.URL.Query().Has(name)
+		})
+	}
+
+	return PredicateFunc("query "+name+"="+value, func(r *http.Request) bool {
+		for _, v := range r.URL.Query()[name] {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Scheme returns a Predicate matching the requests made over the `scheme` URL scheme, "http" or "https".
+// The scheme of a server request is the one of its URL if set, or else "https" for the TLS connections.
+func Scheme(scheme string) Predicate {
+	scheme = strings.ToLower(scheme)
+	return PredicateFunc("scheme "+scheme, func(r *http.Request) bool {
+		return requestScheme(r) == scheme
+	})
+}
+
+// ContentType returns a Predicate matching the requests whose Content-Type header is one of the media types,
+// ignoring the media type parameters, e.g. ContentType("application/json") matches "application/json; charset=utf-8".
+func ContentType(mediaTypes ...string) Predicate {
+	return PredicateFunc("content-type "+strings.Join(mediaTypes, ","), func(r *http.Request) bool {
+		ct, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			return false
+		}
+		for _, mt := range mediaTypes {
+			if strings.EqualFold(ct, mt) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func requestScheme(r *http.Request) string {
+	if r.URL.Scheme != "" {
+		return strings.ToLower(r.URL.Scheme)
+	}
+	if r.TLS != nil {
+		return "https"
+	}
+	return "http"
+}
+
+// Where returns an inline Router whose routes only serve the requests matching all of the predicates.
+// The predicates of nested inline routers add up.
+func (mx *Mux) Where(predicates ...Predicate) Router {
+	if len(predicates) == 0 {
+		panic("gor: attempting to Where() a route without predicates")
+	}
+
+	im := mx.With().(*Mux)
+	im.predicates = append(im.predicates[:len(im.predicates):len(im.predicates)], predicates...)
+
+	return im
+}
+
+// routeVariant is a handler of an endpoint serving the requests matching its predicates,
+// along with the metadata of the route it was registered with.
+type routeVariant struct {
+	predicates []Predicate
+	handler    http.Handler
+	meta       Meta
+}
+
+func (v *routeVariant) match(r *http.Request) bool {
+	for _, p := range v.predicates {
+		if !p.Match(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// variantHandler is the handler of an endpoint registered with predicates, dispatching the requests
+// to the first of its variants matching them, or else to the fallback registered without predicates.
+// It is never modified once set on an endpoint, so that copies of the routing tree can share it.
+type variantHandler struct {
+	variants []routeVariant
+	fallback routeVariant
+}
+
+// variant returns the variant serving the request, or nil if none matches it.
+func (vh *variantHandler) variant(r *http.Request) *routeVariant {
+	for i := range vh.variants {
+		if vh.variants[i].match(r) {
+			return &vh.variants[i]
+		}
+	}
+	if vh.fallback.handler != nil {
+		return &vh.fallback
+	}
+	return nil
+}
+
+func (vh *variantHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if v := vh.variant(r); v != nil {
+		v.handler.ServeHTTP(w, r)
+		return
+	}
+	http.NotFound(w, r)
+}
+
+// mergeVariants returns the handler of an endpoint registering the variants of `vh` after the ones of its `current`
+// handler. The current handler without predicates, registered with the `meta` metadata, remains the fallback.
+func mergeVariants(current http.Handler, meta Meta, vh *variantHandler) *variantHandler {
+	cv, ok := current.(*variantHandler)
+	if !ok {
+		return &variantHandler{variants: vh.variants, fallback: routeVariant{handler: current, meta: meta}}
+	}
+
+	variants := append(cv.variants[:len(cv.variants):len(cv.variants)], vh.variants...)
+	return &variantHandler{variants: variants, fallback: cv.fallback}
+}
+
+// routeVariants returns the variants of an endpoint handler, followed by its handler without predicates, if any.
+// The variant of a handler without predicates has the metadata of the endpoint, `meta`.
+func routeVariants(handler http.Handler, meta Meta) []routeVariant {
+	vh, ok := handler.(*variantHandler)
+	if !ok {
+		return []routeVariant{{handler: handler, meta: meta}}
+	}
+
+	if vh.fallback.handler == nil {
+		return vh.variants
+	}
+	return append(vh.variants[:len(vh.variants):len(vh.variants)], vh.fallback)
+}
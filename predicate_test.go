@@ -0,0 +1,157 @@
+package gor
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMuxWhere(t *testing.T) {
+	r := NewRouter()
+	r.NotFound(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(404)
+		w.Write([]byte("nothing here"))
+	})
+
+	r.Where(Query("format", "csv")).Get("/reports", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("csv"))
+	})
+	r.Where(Header("X-API-Version", "2")).Get("/reports", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("v2"))
+	})
+	r.Get("/reports", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("default"))
+	})
+	r.With(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Mw", "json")
+			next.ServeHTTP(w, r)
+		})
+	}).Where(ContentType("application/json")).Where(Scheme("http")).Post("/reports", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("json"))
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	if _, body := testRequest(t, ts, "GET", "/reports", nil); body != "default" {
+		t.Fatalf(body)
+	}
+	if _, body := testRequest(t, ts, "GET", "/reports?format=csv", nil); body != "csv" {
+		t.Fatalf(body)
+	}
+	if _, body := testRequest(t, ts, "GET", "/reports?format=pdf", nil); body != "default" {
+		t.Fatalf(body)
+	}
+
+	req, _ := http.NewRequest("GET", ts.URL+"/reports?format=csv", nil)
+	req.Header.Set("X-API-Version", "2")
+	if _, body := testClientRequest(t, req); body != "csv" {
+		t.Fatalf("expecting the first matching route, got %s", body)
+	}
+
+	req, _ = http.NewRequest("GET", ts.URL+"/reports", nil)
+	req.Header.Set("X-Api-Version", "2")
+	if _, body := testClientRequest(t, req); body != "v2" {
+		t.Fatalf(body)
+	}
+
+	req, _ = http.NewRequest("POST", ts.URL+"/reports", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if resp, body := testClientRequest(t, req); body != "json" || resp.Header.Get("X-Mw") != "json" {
+		t.Fatalf(body)
+	}
+
+	req, _ = http.NewRequest("POST", ts.URL+"/reports", strings.NewReader("a=1"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if resp, body := testClientRequest(t, req); resp.StatusCode != 404 || body != "nothing here" {
+		t.Fatalf("%d %s", resp.StatusCode, body)
+	}
+
+	var routes []string
+	WalkRoutes(r, func(rt RouteInfo) error {
+		var preds []string
+		for _, p := range rt.Predicates {
+			preds = append(preds, p.String())
+		}
+		routes = append(routes, rt.Method+" "+rt.Pattern+" ["+strings.Join(preds, ", ")+"]")
+		return nil
+	})
+
+	expected := []string{
+		"GET /reports [query format=csv]",
+		"GET /reports [header X-Api-Version=2]",
+		"GET /reports []",
+		"POST /reports [content-type application/json, scheme http]",
+	}
+	if len(routes) != len(expected) {
+		t.Fatalf("expecting routes %v, got %v", expected, routes)
+	}
+	// the methods are visited in any order, the variants of a method in order
+	var get, post []string
+	for _, rt := range routes {
+		if strings.HasPrefix(rt, "GET") {
+			get = append(get, rt)
+		} else {
+			post = append(post, rt)
+		}
+	}
+	if strings.Join(append(get, post...), "\n") != strings.Join(expected, "\n") {
+		t.Fatalf("expecting routes %v, got %v", expected, routes)
+	}
+}
+
+func TestMuxWhereFallback(t *testing.T) {
+	h := func(body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			name, _ := RouteContext(r.Context()).RouteMeta()["name"].(string)
+			w.Write([]byte(body + " " + name))
+		}
+	}
+
+	// the routes with predicates are registered before the fallback, which is then replaced
+	r := NewRouter()
+	r.Where(Query("format", "csv")).Meta(Meta{"name": "csv"}).Get("/reports", h("csv"))
+	r.Meta(Meta{"name": "first"}).Get("/reports", h("first"))
+	r.Where(Query("format", "pdf")).Get("/reports", h("pdf"))
+	r.Meta(Meta{"name": "plain"}).Get("/reports", h("plain"))
+
+	// the routes keep the metadata they were registered with
+	for _, tt := range []struct{ path, body string }{
+		{"/reports", "plain plain"},
+		{"/reports?format=csv", "csv csv"},
+		{"/reports?format=pdf", "pdf "},
+	} {
+		if _, body := testHandler(t, r, "GET", tt.path, nil); body != tt.body {
+			t.Fatalf("%s: expecting %q, got %q", tt.path, tt.body, body)
+		}
+	}
+
+	var names []string
+	WalkRoutes(r, func(rt RouteInfo) error {
+		name, _ := rt.Meta["name"].(string)
+		names = append(names, name)
+		return nil
+	})
+	if strings.Join(names, ",") != "csv,,plain" {
+		t.Fatalf("unexpected walked metadata %v", names)
+	}
+}
+
+func testClientRequest(t *testing.T, req *http.Request) (*http.Response, string) {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+		return nil, ""
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+		return nil, ""
+	}
+	return resp, string(body)
+}
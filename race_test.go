@@ -0,0 +1,7 @@
+//go:build race
+
+package gor
+
+// raceEnabled reports whether the tests run with the race detector, under which sync.Pool drops
+// some of the pooled values and the allocations cannot be counted.
+const raceEnabled = true
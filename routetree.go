@@ -0,0 +1,129 @@
+package gor
+
+import "sort"
+
+// RouteTree is a snapshot of the routing tree of a Mux, as returned by Mux.RouteTree,
+// for the tools generating code or documentation from the structure of the routes.
+type RouteTree struct {
+	// Root is the root node of the tree.
+	Root *RouteNode
+
+	// Methods are the HTTP methods known to the router, sorted.
+	Methods []string
+
+	// CaseInsensitive, CleanPath and TrailingSlash are the path matching options of the mux,
+	// under which the routing path may be rewritten before or after the lookup in the tree.
+	CaseInsensitive bool
+	CleanPath       bool
+	TrailingSlash   TrailingSlash
+
+	// Hosts are the host patterns of the host routers of the mux, matched before the tree.
+	Hosts []string
+}
+
+// RouteNode is a node of a RouteTree.
+type RouteNode struct {
+	// Kind is the kind of the node: StepStatic, StepRegexp, StepParam or StepCatchAll.
+	Kind StepKind
+
+	// Prefix is the static prefix of a static node, or the regexp or the param type of a regexp node.
+	// See MatchParam for matching the values of regexp nodes.
+	Prefix string
+
+	// Typed reports whether the Prefix of a regexp node is the name of a param type, see RegisterParamType.
+	Typed bool
+
+	// Tail is the byte ending the values of a param or regexp node.
+	Tail byte
+
+	// Leaf reports whether routes end on the node, even if none of them has a handler left.
+	Leaf bool
+
+	// Endpoints are the routes ending on the node, by method, for the methods with a handler.
+	Endpoints map[string]RouteEndpoint
+
+	// SubRoutes is the sub-router mounted on the node, if any.
+	SubRoutes Routes
+
+	// Children are the child nodes, in the order they are looked up.
+	Children []*RouteNode
+}
+
+// RouteEndpoint is a route ending on a RouteNode.
+type RouteEndpoint struct {
+	// Pattern is the routing pattern of the route, as recorded in Context.RoutePatterns.
+	Pattern string
+
+	// ParamKeys are the keys of the URL params of the route, in the order of their values.
+	ParamKeys []string
+}
+
+// RouteTree returns a snapshot of the routing tree of the mux. Sub-routers are not expanded,
+// see RouteNode.SubRoutes.
+func (mx *Mux) RouteTree() *RouteTree {
+	custom := mx.owner().methods.names()
+	t := &RouteTree{Root: newRouteNode(mx.tree.load(), custom)}
+
+	for m := range methodMap {
+		t.Methods = append(t.Methods, m)
+	}
+	t.Methods = append(t.Methods, custom...)
+	sort.Strings(t.Methods)
+
+	if mx.cfg != nil {
+		t.CaseInsensitive = mx.cfg.caseInsensitive
+		t.CleanPath = mx.cfg.cleanPath
+		t.TrailingSlash = mx.cfg.trailingSlash
+	}
+
+	for _, hr := range mx.hosts {
+		t.Hosts = append(t.Hosts, hr.pattern)
+	}
+
+	return t
+}
+
+// newRouteNode returns the view of a node, whose routes of all of the methods also serve the custom methods.
+func newRouteNode(n *node, custom []string) *RouteNode {
+	rn := &RouteNode{
+		Kind:      nodeStepKinds[n.ntype],
+		Prefix:    n.prefix,
+		Tail:      n.tail,
+		Typed:     n.match != nil,
+		Leaf:      n.isLeaf(),
+		SubRoutes: n.subroutes,
+	}
+
+	for mt, h := range n.endpoints {
+		m := methodTypeString(mt)
+		if m == "" || h.handler == nil {
+			continue
+		}
+		if rn.Endpoints == nil {
+			rn.Endpoints = make(map[string]RouteEndpoint)
+		}
+		rn.Endpoints[m] = RouteEndpoint{Pattern: h.pattern, ParamKeys: h.paramKeys}
+	}
+
+	if h := n.endpoints[mALL]; h != nil && h.handler != nil {
+		for _, m := range custom {
+			if _, ok := rn.Endpoints[m]; !ok {
+				rn.Endpoints[m] = RouteEndpoint{Pattern: h.pattern, ParamKeys: h.paramKeys}
+			}
+		}
+	}
+
+	for _, nds := range n.child {
+		for _, cn := range nds {
+			rn.Children = append(rn.Children, newRouteNode(cn, custom))
+		}
+	}
+
+	return rn
+}
+
+// MatchParam reports whether a param value is accepted by a param type or a regexp pattern,
+// as the values of the `{param:type}` and `{param:regexp}` segments of the routing patterns.
+func MatchParam(rexpat, value string) bool {
+	return matchParamValue(rexpat, value)
+}
@@ -0,0 +1,104 @@
+package gor
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestMuxRouteTree(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	sub := NewRouter()
+	sub.Get("/", h)
+
+	r := NewMux(WithCleanPath())
+	r.Get("/users", h)
+	r.Post("/users", h)
+	r.Get("/users/{id:int}", h)
+	r.Get("/users/{name}/{file:[a-z]+}.{ext}", h)
+	r.Mount("/admin", sub)
+
+	tree := r.RouteTree()
+	if !tree.CleanPath || tree.CaseInsensitive || tree.TrailingSlash != TrailingSlashStrict {
+		t.Fatalf("unexpected options %+v", tree)
+	}
+	if !sort.StringsAreSorted(tree.Methods) {
+		t.Fatalf("unsorted methods %v", tree.Methods)
+	}
+	for m := range methodMap {
+		if i := sort.SearchStrings(tree.Methods, m); i == len(tree.Methods) || tree.Methods[i] != m {
+			t.Fatalf("missing method %s in %v", m, tree.Methods)
+		}
+	}
+
+	var users *RouteNode
+	for _, c := range tree.Root.Children[0].Children {
+		if c.Prefix == "users" {
+			users = c
+		}
+	}
+	if users == nil {
+		t.Fatalf("missing users node")
+	}
+
+	want := map[string]RouteEndpoint{
+		http.MethodGet:  {Pattern: "/users", ParamKeys: []string{}},
+		http.MethodPost: {Pattern: "/users", ParamKeys: []string{}},
+	}
+	if !users.Leaf || !reflect.DeepEqual(users.Endpoints, want) {
+		t.Fatalf("unexpected endpoints %+v", users.Endpoints)
+	}
+
+	// the children are in lookup order: /users/{id:int} before /users/{name}
+	slash := users.Children[0]
+	kinds := []StepKind{slash.Children[0].Kind, slash.Children[1].Kind}
+	if !reflect.DeepEqual(kinds, []StepKind{StepRegexp, StepParam}) {
+		t.Fatalf("unexpected children kinds %v", kinds)
+	}
+	if id := slash.Children[0]; id.Prefix != "int" || !id.Typed || id.Tail != '/' {
+		t.Fatalf("unexpected typed param node %+v", id)
+	}
+
+	file := slash.Children[1].Children[0].Children[0]
+	if file.Kind != StepRegexp || file.Prefix != "^[a-z]+$" || file.Typed || file.Tail != '.' {
+		t.Fatalf("unexpected regexp param node %+v", file)
+	}
+
+	var mount func(n *RouteNode) *RouteNode
+	mount = func(n *RouteNode) *RouteNode {
+		if n.SubRoutes != nil {
+			return n
+		}
+		for _, c := range n.Children {
+			if m := mount(c); m != nil {
+				return m
+			}
+		}
+		return nil
+	}
+	if m := mount(tree.Root); m == nil || m.SubRoutes != sub || m.Kind != StepCatchAll {
+		t.Fatalf("missing mounted sub-router, got %+v", m)
+	}
+}
+
+func TestMatchParam(t *testing.T) {
+	tests := []struct {
+		rexpat string
+		value  string
+		want   bool
+	}{
+		{"int", "-42", true},
+		{"int", "4x", false},
+		{"^[a-z]+$", "abc", true},
+		{"^[a-z]+$", "ab1", false},
+		{"[", "[", false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchParam(tt.rexpat, tt.value); got != tt.want {
+			t.Errorf("MatchParam(%q, %q) = %v, want %v", tt.rexpat, tt.value, got, tt.want)
+		}
+	}
+}
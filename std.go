@@ -0,0 +1,124 @@
+package gor
+
+import (
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+// HandleStd adds a route with a net/http.ServeMux pattern, "[METHOD ][HOST]/[PATH]", e.g. "GET /items/{id}",
+// "example.com/static/" or "/files/{path...}", with the semantics of the ServeMux patterns:
+// a path ending with a slash matches the whole subtree, unless it ends with "{$}", and the wildcards are
+// whole segments, the last one being a `{name...}` wildcard matching the rest of the path.
+// As opposed to ServeMux, "GET" patterns do not match the HEAD requests, see the GetHead middleware.
+// The path params are available with URLParam, and with http.Request.PathValue on Go 1.22 and later.
+func (mx *Mux) HandleStd(pattern string, handler http.Handler) {
+	if err := mx.TryHandleStd(pattern, handler); err != nil {
+		panic(err)
+	}
+}
+
+// TryHandleStd is like HandleStd, but returns a *RouteError instead of panicking
+// for an invalid pattern or an unsupported method.
+func (mx *Mux) TryHandleStd(pattern string, handler http.Handler) error {
+	method, host, path, err := parseStdPattern(pattern)
+	if err != nil {
+		return err
+	}
+
+	if host == "" {
+		return mx.tryHandleMethod(method, path, handler)
+	}
+
+	mx.Host(host, func(r Router) {
+		err = r.(*Mux).tryHandleMethod(method, path, handler)
+	})
+	return err
+}
+
+// tryHandleMethod adds a route for the `method` http method, or for any method if it is empty.
+func (mx *Mux) tryHandleMethod(method, pattern string, handler http.Handler) error {
+	if method == "" {
+		return mx.TryHandle(pattern, handler)
+	}
+	return mx.TryMethod(method, pattern, handler)
+}
+
+// parseStdPattern splits a ServeMux pattern into its method, host and path, translated into a gor routing pattern.
+func parseStdPattern(pattern string) (method, host, path string, err error) {
+	rest := pattern
+	if i := strings.IndexAny(pattern, " \t"); i >= 0 {
+		method, rest = pattern[:i], strings.TrimLeft(pattern[i+1:], " \t")
+	}
+
+	slash := strings.IndexByte(rest, '/')
+	if slash < 0 {
+		return "", "", "", patternError(ErrInvalidPattern, pattern, len(pattern), "host/path missing /")
+	}
+	host = rest[:slash]
+
+	var b strings.Builder
+	pos := len(pattern) - len(rest) + slash
+	segs := strings.Split(rest[slash+1:], "/")
+	for i, seg := range segs {
+		pos++ // the slash
+		b.WriteByte('/')
+		last := i == len(segs)-1
+
+		if !strings.HasPrefix(seg, "{") {
+			if j := strings.IndexAny(seg, "{}"); j >= 0 {
+				return "", "", "", patternError(ErrInvalidPattern, pattern, pos+j, "wildcards must be whole segments")
+			}
+			b.WriteString(seg)
+			pos += len(seg)
+			continue
+		}
+
+		if !strings.HasSuffix(seg, "}") {
+			return "", "", "", patternError(ErrInvalidPattern, pattern, pos, "wildcards must be whole segments")
+		}
+
+		name := seg[1 : len(seg)-1]
+		switch {
+		case name == "$":
+			if !last {
+				return "", "", "", patternError(ErrInvalidPattern, pattern, pos, "{$} not at the end")
+			}
+			// the trailing slash is matched exactly
+			return method, host, b.String(), nil
+		case strings.HasSuffix(name, "..."):
+			if !last {
+				return "", "", "", patternError(ErrInvalidPattern, pattern, pos, "{...} wildcard not at the end")
+			}
+			b.WriteString(seg)
+		default:
+			b.WriteString(seg)
+		}
+
+		if !isIdentifier(strings.TrimSuffix(name, "...")) {
+			return "", "", "", patternError(ErrInvalidPattern, pattern, pos, "bad wildcard name %q", name)
+		}
+		pos += len(seg)
+	}
+
+	path = b.String()
+	if strings.HasSuffix(path, "/") {
+		// a trailing slash matches the subtree
+		path += "*"
+	}
+
+	return method, host, path, nil
+}
+
+// isIdentifier reports whether s is a Go identifier, as required for the names of the ServeMux wildcards.
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, c := range s {
+		if !unicode.IsLetter(c) && c != '_' && (i == 0 || !unicode.IsDigit(c)) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,112 @@
+package gor
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseStdPattern(t *testing.T) {
+	tests := []struct {
+		pattern, method, host, path string
+	}{
+		{"/", "", "", "/*"},
+		{"/{$}", "", "", "/"},
+		{"GET /items/{id}", "GET", "", "/items/{id}"},
+		{"POST\t /items/", "POST", "", "/items/*"},
+		{"/items/{$}", "", "", "/items/"},
+		{"example.com/", "", "example.com", "/*"},
+		{"DELETE api.example.com/files/{path...}", "DELETE", "api.example.com", "/files/{path...}"},
+		{"/a/{b}/c", "", "", "/a/{b}/c"},
+	}
+
+	for _, tt := range tests {
+		method, host, path, err := parseStdPattern(tt.pattern)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.pattern, err)
+		}
+		if method != tt.method || host != tt.host || path != tt.path {
+			t.Fatalf("%q: expecting %q %q %q, got %q %q %q", tt.pattern, tt.method, tt.host, tt.path, method, host, path)
+		}
+	}
+
+	for _, tt := range []struct {
+		pattern string
+		pos     int
+	}{
+		{"GET items", 9},
+		{"/items/x{id}", 8},
+		{"/items/{id", 7},
+		{"/{$}/items", 1},
+		{"/{path...}/raw", 1},
+		{"/items/{id:int}", 7},
+		{"/items/{}", 7},
+	} {
+		_, _, _, err := parseStdPattern(tt.pattern)
+		var re *RouteError
+		if !errors.As(err, &re) || !errors.Is(err, ErrInvalidPattern) || re.Pos != tt.pos {
+			t.Fatalf("%q: expecting an invalid pattern error at %d, got %v", tt.pattern, tt.pos, err)
+		}
+	}
+}
+
+func TestMuxHandleStd(t *testing.T) {
+	r := NewRouter()
+	r.HandleStd("GET /items/{id}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("item " + URLParam(r, "id")))
+	}))
+	r.HandleStd("/items/{$}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("items"))
+	}))
+	r.HandleStd("/static/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("static " + URLParam(r, "*")))
+	}))
+	r.HandleStd("GET files.example.com/files/{path...}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("files " + URLParam(r, "path")))
+	}))
+	r.Route("/api", func(r Router) {
+		r.HandleStd("PUT /users/{name}", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("user " + URLParam(r, "name")))
+		}))
+	})
+
+	tests := []struct {
+		method, host, path string
+		status             int
+		body               string
+	}{
+		{"GET", "", "/items/1", 200, "item 1"},
+		{"POST", "", "/items/1", 405, ""},
+		{"POST", "", "/items/", 200, "items"},
+		{"GET", "", "/items/1/x", 404, ""},
+		{"GET", "", "/static/css/site.css", 200, "static css/site.css"},
+		{"GET", "", "/static/", 200, "static "},
+		{"GET", "files.example.com", "/files/a/b.txt", 200, "files a/b.txt"},
+		{"GET", "", "/files/a/b.txt", 404, ""},
+		{"PUT", "", "/api/users/ann", 200, "user ann"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		if tt.host != "" {
+			req.Host = tt.host
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Code != tt.status || tt.status == 200 && w.Body.String() != tt.body {
+			t.Fatalf("%s %s%s: expecting %d %q, got %d %q", tt.method, tt.host, tt.path, tt.status, tt.body, w.Code, w.Body.String())
+		}
+	}
+
+	if err := r.TryHandleStd("FETCH /items", http.NotFoundHandler()); !errors.Is(err, ErrUnsupportedMethod) {
+		t.Fatalf("expecting an unsupported method error, got %v", err)
+	}
+	defer func() {
+		if rec := recover(); rec == nil {
+			t.Fatal("expecting a panic for the invalid pattern")
+		}
+	}()
+	r.HandleStd("/items/{id", http.NotFoundHandler())
+}
@@ -0,0 +1,193 @@
+package gor
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// routeTree holds the routing tree of a mux, shared with its inline muxes.
+// The routing reads the tree without locks, while the changes of the routes are serialized.
+// With dynamic routes, the changes are made on a copy of the tree which then atomically replaces it,
+// so that the requests being routed keep seeing a consistent tree.
+type routeTree struct {
+	// root node of the current tree
+	root atomic.Pointer[node]
+
+	// serializes the changes of the tree
+	mu sync.Mutex
+}
+
+func newRouteTree() *routeTree {
+	t := &routeTree{}
+	t.root.Store(&node{})
+	return t
+}
+
+// load returns the root node of the current tree.
+func (t *routeTree) load() *node {
+	return t.root.Load()
+}
+
+// update applies the changes of fn to the tree, on a copy of the tree swapped atomically when `cow` is set.
+// Returning false from fn discards the copy.
+func (t *routeTree) update(cow bool, fn func(root *node) bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	root := t.root.Load()
+	if cow {
+		root = root.clone()
+		root.reindexStatic()
+	}
+
+	if fn(root) && cow {
+		t.root.Store(root)
+	}
+}
+
+// clone returns a deep copy of the node and of its children.
+// Handlers, matchers and sub-routers are shared with the copy, and the static routes index is not copied.
+func (n *node) clone() *node {
+	cn := *n
+	cn.static = nil
+
+	for t, nds := range n.child {
+		if len(nds) == 0 {
+			continue
+		}
+		cn.child[t] = make(nodes, len(nds))
+		for i, c := range nds {
+			cn.child[t][i] = c.clone()
+		}
+	}
+
+	if n.endpoints != nil {
+		cn.endpoints = make(endpoints, len(n.endpoints))
+		for mt, h := range n.endpoints {
+			eh := *h
+			cn.endpoints[mt] = &eh
+		}
+	}
+
+	return &cn
+}
+
+// findNode returns the node the routing pattern was inserted on, along with its ancestors from the root down,
+// or nil if the pattern is not on the tree. The pattern must be valid and have no optional params.
+func (n *node) findNode(pattern string) (*node, []*node) {
+	var path []*node
+	search := pattern
+
+	for search != "" {
+		var segTail byte
+		var segEndIdx int
+		var segType nodeType
+		var segRexpat string
+		var prefix string
+
+		label := search[0]
+		if label == '{' || label == '*' {
+			segType, _, segRexpat, segTail, _, segEndIdx = patNextSegment(search)
+			if segType == ntCatchAll {
+				label = '*'
+			}
+		}
+
+		if segType == ntRegexp {
+			prefix = segRexpat
+		}
+
+		path = append(path, n)
+		n = n.getEdge(segType, label, segTail, prefix)
+		if n == nil {
+			return nil, nil
+		}
+
+		if n.ntype > ntStatic {
+			search = search[segEndIdx:]
+			continue
+		}
+
+		if !strings.HasPrefix(search, n.prefix) {
+			return nil, nil
+		}
+		search = search[len(n.prefix):]
+	}
+
+	return n, path
+}
+
+// removeRoute removes the endpoints of the method type registered with the routing pattern,
+// pruning the nodes left without endpoints, sub-routers and children.
+// It reports whether the pattern had endpoints of the method type.
+func (n *node) removeRoute(method methodType, pattern string) bool {
+	removed := false
+
+	for _, route := range patExpandOptional(pattern) {
+		rn, path := n.findNode(route)
+		if rn == nil || !rn.removeEndpoints(method, pattern) {
+			continue
+		}
+		removed = true
+
+		if rn.endpoints == nil {
+			delete(n.static, route)
+		}
+
+		// prune the nodes from the leaf up
+		for i := len(path) - 1; i >= 0 && rn.isEmpty(); i-- {
+			path[i].removeChild(rn)
+			rn = path[i]
+		}
+	}
+
+	return removed
+}
+
+// removeEndpoints removes the endpoints of the method type registered with the pattern.
+func (n *node) removeEndpoints(method methodType, pattern string) bool {
+	removed := false
+	for mt, h := range n.endpoints {
+		if !method.covers(mt) || h.pattern != pattern {
+			continue
+		}
+		delete(n.endpoints, mt)
+		removed = true
+	}
+
+	if !removed {
+		return false
+	}
+
+	// the remaining methods are not registered for all of the methods anymore
+	if method&mALL != mALL {
+		delete(n.endpoints, mALL)
+	}
+
+	if method&mSTUB != 0 {
+		delete(n.endpoints, mSTUB)
+	}
+
+	if len(n.endpoints) == 0 {
+		n.endpoints = nil
+		n.subroutes = nil
+	}
+
+	return true
+}
+
+// isEmpty reports whether the node has no endpoints, sub-routers or children, i.e. it can be pruned.
+func (n *node) isEmpty() bool {
+	return n.endpoints == nil && n.subroutes == nil && !n.hasChildren()
+}
+
+func (n *node) removeChild(child *node) {
+	nds := n.child[child.ntype]
+	for i := range nds {
+		if nds[i] == child {
+			n.child[child.ntype] = append(nds[:i:i], nds[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,315 @@
+package gor
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestMuxRemoveRoute(t *testing.T) {
+	h := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(name))
+		}
+	}
+
+	r := NewRouter()
+	r.Get("/users/{id}", h("get user"))
+	r.Post("/users/{id}", h("post user"))
+	r.Get("/users/{id}/posts", h("user posts"))
+	r.Handle("/ping", h("ping"))
+	r.Get("/reports/{year?}", h("reports"))
+
+	tests := []struct {
+		method string
+		path   string
+		status int
+	}{
+		{"GET", "/users/1", 200},
+		{"POST", "/users/1", 200},
+		{"GET", "/users/1/posts", 200},
+		{"PUT", "/ping", 200},
+		{"GET", "/reports", 200},
+	}
+	check := func() {
+		t.Helper()
+		for _, tt := range tests {
+			if resp, _ := testHandler(t, r, tt.method, tt.path, nil); resp.StatusCode != tt.status {
+				t.Fatalf("%s %s: expecting %d, got %d", tt.method, tt.path, tt.status, resp.StatusCode)
+			}
+		}
+	}
+	check()
+
+	if err := r.RemoveRoute("GET", "/users/{id}"); err != nil {
+		t.Fatal(err)
+	}
+	tests[0].status = 405
+	check()
+
+	if err := r.RemoveRoute("GET", "/users/{id}"); !errors.Is(err, ErrRouteNotFound) {
+		t.Fatalf("expecting %v, got %v", ErrRouteNotFound, err)
+	}
+	if err := r.RemoveRoute("GET", "/users/{uid}/posts"); !errors.Is(err, ErrRouteNotFound) {
+		t.Fatalf("expecting %v for a pattern with other param keys, got %v", ErrRouteNotFound, err)
+	}
+
+	if err := r.RemoveRoute("post", "/users/{id}"); err != nil {
+		t.Fatal(err)
+	}
+	tests[0].status, tests[1].status = 404, 404
+	check()
+
+	if err := r.RemoveRoute("GET", "/ping"); err != nil {
+		t.Fatal(err)
+	}
+	if resp, _ := testHandler(t, r, "GET", "/ping", nil); resp.StatusCode != 405 {
+		t.Fatalf("expecting 405, got %d", resp.StatusCode)
+	}
+	if err := r.RemoveRoute("*", "/ping"); err != nil {
+		t.Fatal(err)
+	}
+	tests[3].status = 404
+
+	if err := r.RemoveRoute("GET", "/reports/{year?}"); err != nil {
+		t.Fatal(err)
+	}
+	tests[4].status = 404
+	check()
+
+	var routes []string
+	Walk(r, func(method, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		routes = append(routes, method+" "+route)
+		return nil
+	})
+	if len(routes) != 1 || routes[0] != "GET /users/{id}/posts" {
+		t.Fatalf("unexpected routes %v", routes)
+	}
+
+	if err := r.RemoveRoute("BOGUS", "/"); !errors.Is(err, ErrUnsupportedMethod) {
+		t.Fatalf("expecting %v, got %v", ErrUnsupportedMethod, err)
+	}
+}
+
+func TestMuxUnmount(t *testing.T) {
+	r := NewRouter()
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("root"))
+	})
+	r.Route("/admin", func(r Router) {
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("admin"))
+		})
+		r.Get("/users", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("admin users"))
+		})
+	})
+	r.Mount("/static/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("static"))
+	}))
+
+	if _, body := testHandler(t, r, "GET", "/admin/users", nil); body != "admin users" {
+		t.Fatalf("unexpected body '%s'", body)
+	}
+
+	if err := r.Unmount("/admin"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Unmount("/static/"); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{"/admin", "/admin/", "/admin/users", "/static/app.js"} {
+		if resp, _ := testHandler(t, r, "GET", path, nil); resp.StatusCode != 404 {
+			t.Fatalf("%s: expecting 404, got %d", path, resp.StatusCode)
+		}
+	}
+	if _, body := testHandler(t, r, "GET", "/", nil); body != "root" {
+		t.Fatalf("unexpected body '%s'", body)
+	}
+
+	if err := r.Unmount("/admin"); !errors.Is(err, ErrRouteNotFound) {
+		t.Fatalf("expecting %v, got %v", ErrRouteNotFound, err)
+	}
+
+	root := NewRouter()
+	root.Mount("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	for _, pattern := range []string{"", "admin"} {
+		if err := root.Unmount(pattern); !errors.Is(err, ErrInvalidPattern) {
+			t.Fatalf("%q: expecting %v, got %v", pattern, ErrInvalidPattern, err)
+		}
+	}
+	if err := root.Unmount("/"); err != nil {
+		t.Fatal(err)
+	}
+
+	// the pattern can be mounted again
+	r.Mount("/admin", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("new admin"))
+	}))
+	if _, body := testHandler(t, r, "GET", "/admin/users", nil); body != "new admin" {
+		t.Fatalf("unexpected body '%s'", body)
+	}
+}
+
+func TestMuxDynamicRoutes(t *testing.T) {
+	r := NewRouter(WithDynamicRoutes())
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("root"))
+	})
+
+	// changes are made on a copy of the tree
+	root := r.tree.load()
+	r.Get("/plugin", func(w http.ResponseWriter, r *http.Request) {})
+	if r.tree.load() == root {
+		t.Fatal("expecting the tree to be replaced")
+	}
+	if root.findPattern("/plugin") {
+		t.Fatal("expecting the previous tree to be left unchanged")
+	}
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				if _, body := testRequest(t, ts, "GET", "/", nil); body != "root" {
+					t.Errorf("unexpected body '%s'", body)
+					return
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 50; i++ {
+		pattern := fmt.Sprintf("/plugins/p%d", i%5)
+		if i < 5 {
+			r.Route(pattern, func(r Router) {
+				r.Get("/", func(w http.ResponseWriter, r *http.Request) {})
+			})
+			continue
+		}
+		if err := r.Unmount(pattern); err != nil {
+			t.Fatal(err)
+		}
+		r.Route(pattern, func(r Router) {
+			r.Get("/", func(w http.ResponseWriter, r *http.Request) {})
+		})
+	}
+	wg.Wait()
+
+	var routes []string
+	Walk(r, func(method, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		routes = append(routes, route)
+		return nil
+	})
+	sort.Strings(routes)
+	if len(routes) != 7 || routes[0] != "/" || routes[6] != "/plugins/p4/" {
+		t.Fatalf("unexpected routes %v", routes)
+	}
+}
+
+func TestTreeStaticIndex(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := NewRouter()
+	r.Get("/healthz", h)
+	r.Get("/users/new", h)
+	r.Post("/users/{id}", h)
+	r.Get("/users/{id}", h)
+	r.Get("/reports/{year?}", h)
+	r.Get("/files/*", h)
+	r.Route("/v1", func(r Router) {
+		r.Get("/metrics", h)
+	})
+
+	root := r.tree.load()
+	var indexed []string
+	for route := range root.static {
+		indexed = append(indexed, route)
+	}
+	sort.Strings(indexed)
+	if expected := []string{"/healthz", "/reports", "/users/new", "/v1", "/v1/"}; !reflect.DeepEqual(indexed, expected) {
+		t.Fatalf("expecting the static routes %v, got %v", expected, indexed)
+	}
+
+	// the index and the traversal find the same routes
+	for _, tt := range []struct {
+		method, path string
+	}{
+		{"GET", "/healthz"},
+		{"GET", "/users/new"},
+		{"POST", "/users/new"},
+		{"PUT", "/users/new"},
+		{"GET", "/reports"},
+		{"GET", "/v1/metrics"},
+		{"GET", "/v1"},
+		{"GET", "/files/a"},
+	} {
+		indexCtx, walkCtx := NewRouteContext(), NewRouteContext()
+		r.Match(indexCtx, tt.method, tt.path)
+
+		walkCtx.trace = &Explanation{}
+		r.Match(walkCtx, tt.method, tt.path)
+
+		if indexCtx.RoutePattern() != walkCtx.RoutePattern() || !reflect.DeepEqual(indexCtx.URLParams, walkCtx.URLParams) ||
+			!reflect.DeepEqual(indexCtx.AllowedMethods(), walkCtx.AllowedMethods()) {
+			t.Fatalf("%s %s: expecting the route %q %v %v, got %q %v %v", tt.method, tt.path,
+				walkCtx.RoutePattern(), walkCtx.URLParams, walkCtx.AllowedMethods(),
+				indexCtx.RoutePattern(), indexCtx.URLParams, indexCtx.AllowedMethods())
+		}
+	}
+
+	// the static route without a handler for the method falls back to the param route
+	rctx := NewRouteContext()
+	if !r.Match(rctx, "POST", "/users/new") || rctx.RoutePattern() != "/users/{id}" {
+		t.Fatalf("expecting the param route, got %q", rctx.RoutePattern())
+	}
+
+	if err := r.RemoveRoute("GET", "/healthz"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := root.static["/healthz"]; ok {
+		t.Fatal("expecting the removed route to be removed from the index")
+	}
+	if err := r.Unmount("/v1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := root.static["/v1"]; ok {
+		t.Fatal("expecting the unmounted route to be removed from the index")
+	}
+}
+
+func TestTreeStaticIndexDynamic(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := NewRouter(WithDynamicRoutes())
+	r.Get("/a", h)
+	r.Get("/b", h)
+	before := r.tree.load()
+
+	// the index of the copy of the tree references the nodes of the copy
+	r.Get("/c", h)
+	after := r.tree.load()
+	if len(before.static) != 2 || len(after.static) != 3 {
+		t.Fatalf("unexpected indexes %v %v", before.static, after.static)
+	}
+	if before.static["/a"] == after.static["/a"] {
+		t.Fatal("expecting the index of the copy to reference the copied nodes")
+	}
+
+	if err := r.RemoveRoute("GET", "/b"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := r.tree.load().static["/b"]; ok || len(after.static) != 3 {
+		t.Fatal("expecting the route to be removed from the index of the new tree only")
+	}
+}
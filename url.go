@@ -0,0 +1,130 @@
+package gor
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// URLFor builds the URL path of the route registered under `name`,
+// substituting the route parameters with the given key/value pairs, e.g.
+//
+//	r.URLFor("user.show", "id", "42")
+//
+// Names registered on mounted sub-routers are resolved as well,
+// with the mount pattern prepended to the route pattern.
+// Values of `{param:regexp}` parameters must match their regexp.
+// Optional parameters without a value are left out of the URL along with the ones following them.
+func (mx *Mux) URLFor(name string, params ...string) (string, error) {
+	pattern, ok := mx.owner().routeNamePattern(name)
+	if !ok {
+		return "", fmt.Errorf("gor: route name '%s' is not registered", name)
+	}
+
+	if len(params)%2 != 0 {
+		return "", fmt.Errorf("gor: odd number of params given to URLFor for route '%s'", name)
+	}
+
+	values := make(map[string]string, len(params)/2)
+	for i := 0; i < len(params); i += 2 {
+		values[params[i]] = params[i+1]
+	}
+
+	return buildURL(name, pattern, values)
+}
+
+// URLFor builds the URL path of the route registered under `name` on
+// the router serving the request. See Mux.URLFor for details.
+func URLFor(r *http.Request, name string, params ...string) (string, error) {
+	rctx := RouteContext(r.Context())
+	if rctx == nil {
+		return "", fmt.Errorf("gor: no routing context to build the URL for route '%s'", name)
+	}
+
+	rt, ok := rctx.Routes.(interface {
+		URLFor(name string, params ...string) (string, error)
+	})
+	if !ok {
+		return "", fmt.Errorf("gor: router does not support URLFor for route '%s'", name)
+	}
+
+	return rt.URLFor(name, params...)
+}
+
+// setRouteName records `pattern` under the route `name`.
+func (mx *Mux) setRouteName(name, pattern string) error {
+	if mx.names == nil {
+		mx.names = make(map[string]string)
+	}
+
+	if p, ok := mx.names[name]; ok && p != pattern {
+		return routeError(ErrDuplicateName, "", pattern, "route name '%s' is already registered for '%s'", name, p)
+	}
+
+	mx.names[name] = pattern
+	return nil
+}
+
+// routeNamePattern looks up the full routing pattern of the route `name`
+// on the mux and recursively on its mounted sub-routers.
+func (mx *Mux) routeNamePattern(name string) (string, bool) {
+	if p, ok := mx.names[name]; ok {
+		return p, true
+	}
+
+	for _, rt := range mx.Routes() {
+		subMux, ok := rt.SubRoutes.(*Mux)
+		if !ok {
+			continue
+		}
+		if p, ok := subMux.owner().routeNamePattern(name); ok {
+			return strings.TrimSuffix(rt.Pattern, "/*") + p, true
+		}
+	}
+
+	return "", false
+}
+
+// buildURL substitutes the params of a routing pattern with `values`.
+func buildURL(name, pattern string, values map[string]string) (string, error) {
+	var b strings.Builder
+	search := pattern
+
+	for {
+		segTyp, key, rexpat, _, ps, pe := patNextSegment(search)
+		if segTyp == ntStatic {
+			b.WriteString(search)
+			return b.String(), nil
+		}
+
+		value, ok := values[key]
+		if !ok && patOptional(search[ps:pe], key) {
+			// absent optional params end the URL
+			u := strings.TrimSuffix(b.String()+search[:ps], "/")
+			if u == "" {
+				u = "/"
+			}
+			return u, nil
+		}
+
+		b.WriteString(search[:ps])
+		search = search[pe:]
+
+		if !ok {
+			return "", fmt.Errorf("gor: missing value for param '%s' of route '%s'", key, name)
+		}
+
+		switch segTyp {
+		case ntCatchAll:
+			b.WriteString((&url.URL{Path: value}).EscapedPath())
+			continue
+		case ntRegexp:
+			if !matchParamValue(rexpat, value) {
+				return "", fmt.Errorf("gor: value '%s' does not match param '%s' of route '%s'", value, key, name)
+			}
+		}
+
+		b.WriteString(url.PathEscape(value))
+	}
+}
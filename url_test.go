@@ -0,0 +1,98 @@
+package gor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestURLFor(t *testing.T) {
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := NewRouter()
+	r.Named("home").Get("/", h)
+	r.Named("article").Get("/articles/{slug}", h)
+	r.Route("/users", func(r Router) {
+		r.Named("user.list").Get("/", h)
+		r.Named("user.show").Get("/{id:[0-9]+}", h)
+		r.Route("/{id}/files", func(r Router) {
+			r.Named("user.file").Get("/*", h)
+		})
+	})
+	r.Route("/hubs/{hubID}", func(r Router) {
+		r.With(func(next http.Handler) http.Handler { return next }).Named("hub.post").Get("/posts/{postID}", h)
+	})
+	r.Named("report").Get("/reports/{year?:[0-9]{4}}/{month?}", h)
+
+	tests := []struct {
+		name   string
+		params []string
+		url    string
+	}{
+		{"home", nil, "/"},
+		{"article", []string{"slug", "hello world"}, "/articles/hello%20world"},
+		{"user.list", nil, "/users/"},
+		{"user.show", []string{"id", "42"}, "/users/42"},
+		{"user.file", []string{"id", "7", "*", "docs/a b.txt"}, "/users/7/files/docs/a%20b.txt"},
+		{"hub.post", []string{"hubID", "h1", "postID", "p2"}, "/hubs/h1/posts/p2"},
+		{"report", nil, "/reports"},
+		{"report", []string{"year", "2024"}, "/reports/2024"},
+		{"report", []string{"year", "2024", "month", "05"}, "/reports/2024/05"},
+	}
+
+	for _, tt := range tests {
+		u, err := r.URLFor(tt.name, tt.params...)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if u != tt.url {
+			t.Fatalf("%s: expecting url '%s', got '%s'", tt.name, tt.url, u)
+		}
+	}
+
+	if _, err := r.URLFor("user.show", "id", "abc"); err == nil {
+		t.Fatal("expecting an error for a value not matching the param regexp")
+	}
+
+	if _, err := r.URLFor("user.show"); err == nil {
+		t.Fatal("expecting an error for a missing param value")
+	}
+
+	if _, err := r.URLFor("nope"); err == nil {
+		t.Fatal("expecting an error for an unknown route name")
+	}
+}
+
+func TestURLForRequest(t *testing.T) {
+	r := NewRouter()
+	r.Named("user.show").Get("/users/{id}", func(w http.ResponseWriter, r *http.Request) {})
+	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+		u, err := URLFor(r, "user.show", "id", "5")
+		if err != nil {
+			t.Error(err)
+		}
+		w.Write([]byte(u))
+	})
+
+	ts := httptest.NewServer(r)
+	defer ts.Close()
+
+	if _, body := testRequest(t, ts, "GET", "/", nil); body != "/users/5" {
+		t.Fatalf(body)
+	}
+}
+
+func TestDuplicateRouteName(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic()")
+		}
+	}()
+
+	h := func(w http.ResponseWriter, r *http.Request) {}
+
+	r := NewRouter()
+	r.Named("dup").Get("/a", h)
+	r.Named("dup").Post("/a", h)
+	r.Named("dup").Get("/b", h)
+}
@@ -0,0 +1,308 @@
+package gor
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Versioned routes the requests of an API to the routers of its versions, e.g.
+//
+//	api := gor.NewVersioned(gor.VersionHeader("X-API-Version"), gor.VersionMediaType("acme"))
+//	api.Version(1, func(r gor.Router) {
+//		r.Get("/users/{id}", getUserV1)
+//		r.Get("/orders", listOrders)
+//	})
+//	api.Version(2, func(r gor.Router) {
+//		r.Get("/users/{id}", getUserV2)
+//	})
+//	api.Deprecate(1, deprecatedAt, sunsetAt)
+//	r.Mount("/api", api)
+//
+// The version of a request is selected by the prefix of its routing path, e.g. "/api/v2/orders",
+// or else by the version header, e.g. "X-API-Version: 2", or else by the vendor media type of
+// its Accept header, e.g. "application/vnd.acme.v2+json", and defaults to the latest version.
+// A request is served by the router of the nearest version up to the selected one having a route for it,
+// so that the routers of the later versions only register the routes which changed,
+// e.g. "/api/v2/orders" is served by the router of the version 1.
+// The requests selecting a version which is not registered are not found.
+// The selected version is recorded in the routing context, see APIVersion.
+type Versioned struct {
+	// versions sorted in ascending order
+	versions []*apiVersion
+
+	// header selecting the version, if any
+	header string
+
+	// media type selecting the version, if any
+	mediaType *regexp.Regexp
+
+	// routing contexts of the route lookups in the versions
+	pool sync.Pool
+}
+
+type apiVersion struct {
+	version int
+	router  *Mux
+
+	// deprecation and sunset dates of a deprecated version
+	deprecated  bool
+	deprecation time.Time
+	sunset      time.Time
+}
+
+// VersionOption configures the version selection of a Versioned router.
+type VersionOption func(v *Versioned)
+
+// VersionHeader selects the version of the requests by the header `name`, set to e.g. "2" or "v2".
+func VersionHeader(name string) VersionOption {
+	return func(v *Versioned) {
+		v.header = name
+	}
+}
+
+// VersionMediaType selects the version of the requests by the media types of the `vendor` in their Accept header,
+// e.g. "application/vnd.acme.v2+json" for the "acme" vendor.
+func VersionMediaType(vendor string) VersionOption {
+	return func(v *Versioned) {
+		v.mediaType = regexp.MustCompile(`^[a-z]+/vnd\.` + regexp.QuoteMeta(strings.ToLower(vendor)) + `\.v([0-9]+)(\+[a-z0-9.-]+)?$`)
+	}
+}
+
+// NewVersioned returns a new Versioned router configured with the options.
+func NewVersioned(opts ...VersionOption) *Versioned {
+	v := &Versioned{}
+	for _, opt := range opts {
+		opt(v)
+	}
+	return v
+}
+
+// Version creates the router of the API `version`, starting at 1, and registers its routes with fn.
+func (v *Versioned) Version(version int, fn func(r Router)) Router {
+	if version < 1 {
+		panic(fmt.Sprintf("gor: attempting to register the invalid API version %d", version))
+	}
+	if v.find(version) != nil {
+		panic(fmt.Sprintf("gor: attempting to register the API version %d twice", version))
+	}
+
+	av := &apiVersion{version: version, router: NewRouter()}
+	if fn != nil {
+		fn(av.router)
+	}
+
+	v.versions = append(v.versions, av)
+	sort.Slice(v.versions, func(i, j int) bool {
+		return v.versions[i].version < v.versions[j].version
+	})
+
+	return av.router
+}
+
+// Deprecate flags the API `version` as deprecated since `deprecation` and retired at `sunset`, either of which can be zero.
+// The responses to the requests of the version then have a Deprecation header, "@" followed by the unix time
+// of the deprecation date or "true", along with a Sunset header set to the sunset date.
+func (v *Versioned) Deprecate(version int, deprecation, sunset time.Time) {
+	av := v.find(version)
+	if av == nil {
+		panic(fmt.Sprintf("gor: attempting to deprecate the unknown API version %d", version))
+	}
+
+	av.deprecated = true
+	av.deprecation = deprecation
+	av.sunset = sunset
+}
+
+func (v *Versioned) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	rctx := RouteContext(r.Context())
+	if rctx == nil {
+		rctx = NewRouteContext()
+		rctx.parentCtx = r.Context()
+		r = r.WithContext(rctx)
+	}
+
+	routePath := rctx.RoutePath
+	if routePath == "" {
+		routePath = r.URL.Path
+		if r.URL.RawPath != "" {
+			routePath = r.URL.RawPath
+		}
+	}
+
+	version, rest, ok := pathVersion(routePath)
+	if ok {
+		rctx.RoutePatterns = append(rctx.RoutePatterns, "/v"+strconv.Itoa(version)+"/*")
+		routePath = rest
+	} else {
+		version = v.requestVersion(r)
+	}
+
+	method := rctx.RouteMethod
+	if method == "" {
+		method = r.Method
+	}
+
+	if v.find(version) == nil {
+		http.NotFound(w, r)
+		return
+	}
+	av := v.route(version, method, routePath)
+
+	if dv := v.nearest(version); dv != nil && dv.deprecated {
+		if dv.deprecation.IsZero() {
+			w.Header().Set("Deprecation", "true")
+		} else {
+			w.Header().Set("Deprecation", "@"+strconv.FormatInt(dv.deprecation.Unix(), 10))
+		}
+		if !dv.sunset.IsZero() {
+			w.Header().Set("Sunset", dv.sunset.UTC().Format(http.TimeFormat))
+		}
+	}
+
+	rctx.RoutePath = routePath
+	rctx.apiVersion = version
+	av.router.ServeHTTP(w, r)
+}
+
+// Routes returns the routers of the versions, mounted on their path prefix, e.g. "/v1/*".
+func (v *Versioned) Routes() []Route {
+	routes := make([]Route, len(v.versions))
+	for i, av := range v.versions {
+		routes[i] = Route{
+			SubRoutes: av.router,
+			Handlers:  map[string]http.Handler{"*": av.router},
+			Pattern:   "/v" + strconv.Itoa(av.version) + "/*",
+		}
+	}
+	return routes
+}
+
+// Middlewares returns nil, the versions having their own middleware stacks.
+func (v *Versioned) Middlewares() Middlewares {
+	return nil
+}
+
+// Match searches the routers of the versions for the route of the method and path,
+// selecting the version by the path prefix only, since there is no request to look the version up in.
+func (v *Versioned) Match(rctx *Context, method, path string) bool {
+	version, rest, ok := pathVersion(path)
+	if ok {
+		path = rest
+	} else if len(v.versions) > 0 {
+		version = v.versions[len(v.versions)-1].version
+	}
+
+	if v.find(version) == nil {
+		return false
+	}
+	av := v.route(version, method, path)
+
+	rctx.RoutePath = path
+	rctx.apiVersion = version
+	return av.router.Match(rctx, method, path)
+}
+
+// route returns the nearest version up to the registered `version` having a route for the method and path,
+// or else `version`.
+func (v *Versioned) route(version int, method, path string) *apiVersion {
+	rctx, _ := v.pool.Get().(*Context)
+	if rctx == nil {
+		rctx = newPooledRouteContext()
+	}
+	defer v.pool.Put(rctx)
+
+	for i := len(v.versions) - 1; i >= 0; i-- {
+		av := v.versions[i]
+		if av.version > version {
+			continue
+		}
+		rctx.Reset()
+		if av.router.Match(rctx, method, path) {
+			return av
+		}
+	}
+	return v.find(version)
+}
+
+// nearest returns the latest version up to `version`, or nil if there is none.
+func (v *Versioned) nearest(version int) *apiVersion {
+	for i := len(v.versions) - 1; i >= 0; i-- {
+		if v.versions[i].version <= version {
+			return v.versions[i]
+		}
+	}
+	return nil
+}
+
+func (v *Versioned) find(version int) *apiVersion {
+	for _, av := range v.versions {
+		if av.version == version {
+			return av
+		}
+	}
+	return nil
+}
+
+// requestVersion returns the version of the request selected by the version header or media type,
+// or else the latest version.
+func (v *Versioned) requestVersion(r *http.Request) int {
+	if v.header != "" {
+		if version, ok := parseVersion(r.Header.Get(v.header)); ok {
+			return version
+		}
+	}
+
+	if v.mediaType != nil {
+		for _, spec := range ParseAccept(r.Header.Get("Accept")) {
+			if m := v.mediaType.FindStringSubmatch(spec.Value); m != nil && spec.Q > 0 {
+				if version, ok := parseVersion(m[1]); ok {
+					return version
+				}
+			}
+		}
+	}
+
+	if len(v.versions) == 0 {
+		return 0
+	}
+	return v.versions[len(v.versions)-1].version
+}
+
+// pathVersion splits the version prefix of a routing path, e.g. "/v2/users" into 2 and "/users".
+func pathVersion(path string) (int, string, bool) {
+	if !strings.HasPrefix(path, "/v") {
+		return 0, path, false
+	}
+
+	prefix, rest, _ := strings.Cut(path[1:], "/")
+	version, ok := parseVersion(prefix)
+	if !ok {
+		return 0, path, false
+	}
+	return version, "/" + rest, true
+}
+
+// parseVersion parses a version such as "2" or "v2".
+func parseVersion(s string) (int, bool) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if s == "" || !isUint(s) {
+		return 0, false
+	}
+
+	version, err := strconv.Atoi(s)
+	return version, err == nil && version > 0
+}
+
+// APIVersion returns the API version of the request selected by a Versioned router, or 0 if there is none.
+func APIVersion(r *http.Request) int {
+	if rctx := RouteContext(r.Context()); rctx != nil {
+		return rctx.APIVersion()
+	}
+	return 0
+}
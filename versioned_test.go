@@ -0,0 +1,95 @@
+package gor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVersioned(t *testing.T) {
+	versionHandler := func(name string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(name + " v" + strconv.Itoa(APIVersion(r)) + " " + RouteContext(r.Context()).RoutePattern()))
+		}
+	}
+
+	api := NewVersioned(VersionHeader("X-API-Version"), VersionMediaType("acme"))
+	api.Version(1, func(r Router) {
+		r.Get("/users/{id}", versionHandler("user1"))
+		r.Get("/orders", versionHandler("orders1"))
+	})
+	api.Version(2, func(r Router) {
+		r.Get("/users/{id}", versionHandler("user2"))
+	})
+
+	deprecation := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	api.Deprecate(1, deprecation, sunset)
+
+	r := NewRouter()
+	r.Mount("/api", api)
+
+	tests := []struct {
+		path   string
+		header string
+		accept string
+		body   string
+	}{
+		{"/api/v1/users/1", "", "", "user1 v1 /api/v1/users/{id}"},
+		{"/api/v2/users/1", "", "", "user2 v2 /api/v2/users/{id}"},
+		{"/api/v2/orders", "", "", "orders1 v2 /api/v2/orders"},
+		{"/api/users/1", "", "", "user2 v2 /api/users/{id}"},
+		{"/api/users/1", "1", "", "user1 v1 /api/users/{id}"},
+		{"/api/users/1", "v2", "", "user2 v2 /api/users/{id}"},
+		{"/api/users/1", "", "application/vnd.acme.v1+json", "user1 v1 /api/users/{id}"},
+		{"/api/users/1", "", "text/html, application/vnd.other.v2+json", "user2 v2 /api/users/{id}"},
+		{"/api/v3/users/1", "", "", "404 page not found\n"},
+		{"/api/users/1", "3", "", "404 page not found\n"},
+		{"/api/v2/nope", "", "", "404 page not found\n"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest("GET", tt.path, nil)
+		if tt.header != "" {
+			req.Header.Set("X-API-Version", tt.header)
+		}
+		if tt.accept != "" {
+			req.Header.Set("Accept", tt.accept)
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		if w.Body.String() != tt.body {
+			t.Fatalf("%s %s %s: expecting %q, got %q", tt.path, tt.header, tt.accept, tt.body, w.Body.String())
+		}
+
+		deprecated := strings.HasPrefix(tt.body, "user1")
+		if got := w.Header().Get("Deprecation"); deprecated != (got != "") {
+			t.Fatalf("%s: unexpected Deprecation header %q", tt.path, got)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/orders", nil))
+	if w.Header().Get("Deprecation") != "@1672531200" || w.Header().Get("Sunset") != "Mon, 01 Jan 2024 00:00:00 GMT" {
+		t.Fatalf("unexpected deprecation headers %v", w.Header())
+	}
+
+	if !r.Match(NewRouteContext(), "GET", "/api/v2/orders") || r.Match(NewRouteContext(), "GET", "/api/v2/nope") || r.Match(NewRouteContext(), "GET", "/api/v3/orders") {
+		t.Fatal("unexpected match of the versioned routes")
+	}
+
+	var routes []string
+	Walk(r, func(method, route string, handler http.Handler, middlewares ...func(http.Handler) http.Handler) error {
+		routes = append(routes, method+" "+route)
+		return nil
+	})
+	sort.Strings(routes)
+	if strings.Join(routes, ", ") != "GET /api/v1/orders, GET /api/v1/users/{id}, GET /api/v2/users/{id}" {
+		t.Fatalf("unexpected routes %v", routes)
+	}
+}